@@ -0,0 +1,445 @@
+package typed
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Returns a big.Int at the key, or nil if it doesn't
+// exist or isn't an integer
+func (t Typed) BigInt(key string) *big.Int {
+	return t.BigIntOr(key, nil)
+}
+
+// Returns a big.Int at the key, or the specified
+// value if it doesn't exist or isn't an integer
+func (t Typed) BigIntOr(key string, d *big.Int) *big.Int {
+	if value, exists := t.BigIntIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a big.Int or panics
+func (t Typed) BigIntMust(key string) *big.Int {
+	i, exists := t.BigIntIf(key)
+	if exists == false {
+		panic(t.keyError(key, "big.Int"))
+	}
+	return i
+}
+
+// Returns a big.Int or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) BigIntE(key string) (*big.Int, error) {
+	i, exists := t.BigIntIf(key)
+	if exists == false {
+		return i, t.keyError(key, "big.Int")
+	}
+	return i, nil
+}
+
+// Returns a big.Int at the key and whether or not the key existed
+// and the value was an integer. A json.Number is converted from its
+// exact text, so IDs of any size are preserved. Values with a
+// fractional part aren't integers
+func (t Typed) BigIntIf(key string) (*big.Int, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toBigInt(value)
+}
+
+func toBigInt(value interface{}) (*big.Int, bool) {
+	switch t := value.(type) {
+	case *big.Int:
+		return t, t != nil
+	case string:
+		return new(big.Int).SetString(t, 10)
+	case json.Number:
+		if i, ok := new(big.Int).SetString(string(t), 10); ok {
+			return i, true
+		}
+	}
+	if r, ok := toRat(value); ok && r.IsInt() {
+		return new(big.Int).Set(r.Num()), true
+	}
+	return nil, false
+}
+
+// Returns a big.Float at the key, or nil if it doesn't
+// exist or isn't a number
+func (t Typed) BigFloat(key string) *big.Float {
+	return t.BigFloatOr(key, nil)
+}
+
+// Returns a big.Float at the key, or the specified
+// value if it doesn't exist or isn't a number
+func (t Typed) BigFloatOr(key string, d *big.Float) *big.Float {
+	if value, exists := t.BigFloatIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a big.Float or panics
+func (t Typed) BigFloatMust(key string) *big.Float {
+	f, exists := t.BigFloatIf(key)
+	if exists == false {
+		panic(t.keyError(key, "big.Float"))
+	}
+	return f
+}
+
+// Returns a big.Float or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) BigFloatE(key string) (*big.Float, error) {
+	f, exists := t.BigFloatIf(key)
+	if exists == false {
+		return f, t.keyError(key, "big.Float")
+	}
+	return f, nil
+}
+
+// Returns a big.Float at the key and whether or not the key existed
+// and the value was a number. A json.Number (or a string) is parsed
+// with enough precision for all of its digits, rather than going
+// through a float64
+func (t Typed) BigFloatIf(key string) (*big.Float, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toBigFloat(value)
+}
+
+func toBigFloat(value interface{}) (*big.Float, bool) {
+	switch t := value.(type) {
+	case *big.Float:
+		return t, t != nil
+	case string:
+		return parseBigFloat(t)
+	case json.Number:
+		return parseBigFloat(string(t))
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return nil, false
+		}
+		return big.NewFloat(t), true
+	case float32:
+		return toBigFloat(float64(t))
+	}
+	if i, ok := toBigInt(value); ok {
+		return new(big.Float).SetInt(i), true
+	}
+	return nil, false
+}
+
+func parseBigFloat(s string) (*big.Float, bool) {
+	// ~3.3 bits per decimal digit
+	prec := uint(len(s) * 4)
+	if prec < 64 {
+		prec = 64
+	}
+	f, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+	if err != nil || f.IsInf() {
+		return nil, false
+	}
+	return f, true
+}
+
+// Returns a decimal at the key, or an empty string if it
+// doesn't exist or isn't a number
+func (t Typed) Decimal(key string) string {
+	return t.DecimalOr(key, "")
+}
+
+// Returns a decimal at the key, or the specified
+// value if it doesn't exist or isn't a number
+func (t Typed) DecimalOr(key string, d string) string {
+	if value, exists := t.DecimalIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a decimal or panics
+func (t Typed) DecimalMust(key string) string {
+	d, exists := t.DecimalIf(key)
+	if exists == false {
+		panic(t.keyError(key, "decimal"))
+	}
+	return d
+}
+
+// Returns a decimal or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) DecimalE(key string) (string, error) {
+	d, exists := t.DecimalIf(key)
+	if exists == false {
+		return d, t.keyError(key, "decimal")
+	}
+	return d, nil
+}
+
+// Returns a decimal at the key and whether or not the key existed
+// and the value was a number. The decimal is a string of digits with
+// an optional sign and decimal point (e.g. "-19.90"): the digits of a
+// json.Number (or numeric string) are kept exactly as they are, with
+// only an exponent expanded ("1.5e2" is "150"). This makes it suitable
+// for currency, where a float64 would round
+func (t Typed) DecimalIf(key string) (string, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return "", false
+	}
+	return toDecimal(value)
+}
+
+func toDecimal(value interface{}) (string, bool) {
+	switch t := value.(type) {
+	case string:
+		return parseDecimal(t)
+	case json.Number:
+		return parseDecimal(string(t))
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return "", false
+		}
+		return strconv.FormatFloat(t, 'f', -1, 64), true
+	case float32:
+		if math.IsNaN(float64(t)) || math.IsInf(float64(t), 0) {
+			return "", false
+		}
+		return strconv.FormatFloat(float64(t), 'f', -1, 32), true
+	}
+	if i, ok := toBigInt(value); ok {
+		return i.String(), true
+	}
+	return "", false
+}
+
+// Validates a JSON-style number and expands its exponent, if any
+func parseDecimal(s string) (string, bool) {
+	if exponentInRange(s) == false {
+		return "", false
+	}
+	mantissa, exponent := s, 0
+	if e := strings.IndexAny(s, "eE"); e != -1 {
+		mantissa = s[:e]
+		exponent, _ = strconv.Atoi(s[e+1:])
+	}
+
+	sign := ""
+	if len(mantissa) > 0 && mantissa[0] == '-' {
+		sign, mantissa = "-", mantissa[1:]
+	}
+	whole, fraction := mantissa, ""
+	if dot := strings.IndexByte(mantissa, '.'); dot != -1 {
+		whole, fraction = mantissa[:dot], mantissa[dot+1:]
+		if len(fraction) == 0 {
+			return "", false
+		}
+	}
+	if len(whole) == 0 || isDigits(whole) == false || isDigits(fraction) == false {
+		return "", false
+	}
+	if exponent == 0 {
+		return s[:len(sign)+len(mantissa)], true
+	}
+
+	digits := whole + fraction
+	point := len(whole) + exponent
+	if point <= 0 {
+		digits = strings.Repeat("0", 1-point) + digits
+		point = 1
+	} else if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	whole, fraction = strings.TrimLeft(digits[:point], "0"), digits[point:]
+	if whole == "" {
+		whole = "0"
+	}
+	if fraction == "" {
+		return sign + whole, true
+	}
+	return sign + whole + "." + fraction, true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns an slice of integers, or a nil slice
+func (t Typed) BigInts(key string) []*big.Int {
+	return t.BigIntsOr(key, nil)
+}
+
+// Returns an slice of integers, or the specified slice
+// if the key doesn't exist or isn't a valid []*big.Int
+func (t Typed) BigIntsOr(key string, d []*big.Int) []*big.Int {
+	n, ok := t.BigIntsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a integer slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid integer)
+// Values are converted the same way as BigIntIf
+func (t Typed) BigIntsIf(key string) ([]*big.Int, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]*big.Int, len(a))
+	for i, v := range a {
+		if n[i], ok = toBigInt(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+// Returns an map[string]*big.Int
+// (returns nil if one of the values is not a valid integer)
+func (t Typed) StringBigInt(key string) map[string]*big.Int {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]*big.Int, len(raw))
+	for k, value := range raw {
+		v, ok := toBigInt(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an slice of numbers, or a nil slice
+func (t Typed) BigFloats(key string) []*big.Float {
+	return t.BigFloatsOr(key, nil)
+}
+
+// Returns an slice of numbers, or the specified slice
+// if the key doesn't exist or isn't a valid []*big.Float
+func (t Typed) BigFloatsOr(key string, d []*big.Float) []*big.Float {
+	n, ok := t.BigFloatsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a number slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid number)
+// Values are converted the same way as BigFloatIf
+func (t Typed) BigFloatsIf(key string) ([]*big.Float, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]*big.Float, len(a))
+	for i, v := range a {
+		if n[i], ok = toBigFloat(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+// Returns an map[string]*big.Float
+// (returns nil if one of the values is not a valid number)
+func (t Typed) StringBigFloat(key string) map[string]*big.Float {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]*big.Float, len(raw))
+	for k, value := range raw {
+		v, ok := toBigFloat(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an slice of decimals, or a nil slice
+func (t Typed) Decimals(key string) []string {
+	return t.DecimalsOr(key, nil)
+}
+
+// Returns an slice of decimals, or the specified slice
+// if the key doesn't exist or isn't a valid []string
+func (t Typed) DecimalsOr(key string, d []string) []string {
+	n, ok := t.DecimalsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a decimal slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid decimal)
+// Values are converted the same way as DecimalIf
+func (t Typed) DecimalsIf(key string) ([]string, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]string, len(a))
+	for i, v := range a {
+		if n[i], ok = toDecimal(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+// Returns an map[string]string
+// (returns nil if one of the values is not a valid decimal)
+func (t Typed) StringDecimal(key string) map[string]string {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]string, len(raw))
+	for k, value := range raw {
+		v, ok := toDecimal(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
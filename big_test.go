@@ -0,0 +1,85 @@
+package typed
+
+import (
+	"math/big"
+	"testing"
+)
+
+func Test_BigInt(t *testing.T) {
+	typed, _ := JsonString(`{"id": 123456789012345678901234567890, "exp": 1e21, "str": "-98765432109876543210", "small": 42, "frac": 1.5, "bad": "1.0", "nope": true}`)
+	equal(t, typed.BigInt("id").String(), "123456789012345678901234567890")
+	equal(t, typed.BigInt("exp").String(), "1000000000000000000000")
+	equal(t, typed.BigInt("str").String(), "-98765432109876543210")
+	equal(t, typed.BigIntMust("small").Int64(), int64(42))
+	equal(t, New(build("f", 1e20)).BigInt("f").String(), "100000000000000000000")
+	equal(t, typed.BigIntOr("other", big.NewInt(7)).Int64(), int64(7))
+	for _, key := range []string{"frac", "bad", "nope", "other"} {
+		_, ok := typed.BigIntIf(key)
+		equal(t, ok, false)
+		equal(t, typed.BigInt(key) == nil, true)
+	}
+}
+
+func Test_BigFloat(t *testing.T) {
+	typed, _ := JsonString(`{"pi": 3.14159265358979323846264338327950288, "int": 12345678901234567890123, "str": "0.1", "nope": "x"}`)
+	equal(t, typed.BigFloat("pi").Text('f', 35), "3.14159265358979323846264338327950288")
+	equal(t, typed.BigFloat("int").Text('f', 0), "12345678901234567890123")
+	equal(t, typed.BigFloatMust("str").Text('g', 10), "0.1")
+	equal(t, New(build("f", 0.5)).BigFloat("f").Text('f', 1), "0.5")
+	equal(t, New(build("i", 3)).BigFloat("i").Text('f', 0), "3")
+	_, ok := typed.BigFloatIf("nope")
+	equal(t, ok, false)
+	_, ok = New(build("inf", "Inf")).BigFloatIf("inf")
+	equal(t, ok, false)
+	equal(t, typed.BigFloatOr("other", big.NewFloat(2)).Text('f', 0), "2")
+}
+
+func Test_Decimal(t *testing.T) {
+	typed, _ := JsonString(`{"price": 19.90, "neg": -0.001, "int": 7, "exp": 1.5e2, "small": 25e-4, "str": "100.00", "f": 0, "bad": "1.2.3", "nope": true}`)
+	typed["f"] = 0.1
+	equal(t, typed.Decimal("price"), "19.90")
+	equal(t, typed.Decimal("neg"), "-0.001")
+	equal(t, typed.Decimal("int"), "7")
+	equal(t, typed.Decimal("exp"), "150")
+	equal(t, typed.Decimal("small"), "0.0025")
+	equal(t, typed.DecimalMust("str"), "100.00")
+	equal(t, typed.Decimal("f"), "0.1")
+	equal(t, typed.DecimalOr("bad", "0"), "0")
+	equal(t, typed.Decimal("nope"), "")
+	_, ok := typed.DecimalIf("other")
+	equal(t, ok, false)
+}
+
+func Test_ParseDecimal(t *testing.T) {
+	valid := map[string]string{
+		"0": "0", "-12.340": "-12.340", "1e3": "1000", "1.50E1": "15.0", "12.5e-1": "1.25",
+		"1e-2": "0.01", "-1.5e+2": "-150", "123e-3": "0.123", "0.5e1": "5",
+	}
+	for input, expected := range valid {
+		d, ok := parseDecimal(input)
+		equal(t, ok, true)
+		equal(t, d, expected)
+	}
+	for _, input := range []string{"", "-", ".5", "5.", "+5", "1e", "1e1.5", "0x10", "1,5", "NaN", "1e99999"} {
+		_, ok := parseDecimal(input)
+		equal(t, ok, false)
+	}
+}
+
+func Test_BigSlicesAndMaps(t *testing.T) {
+	typed, _ := JsonString(`{"ids": [1, 98765432109876543210], "prices": {"a": 1.10, "b": "2"}, "bad": [1, 1.5], "badMap": {"a": "x"}}`)
+	ids := typed.BigInts("ids")
+	equal(t, ids[1].String(), "98765432109876543210")
+	_, ok := typed.BigIntsIf("bad")
+	equal(t, ok, false)
+	equal(t, len(typed.BigFloats("bad")), 2)
+	equalList(t, typed.Decimals("ids"), []string{"1", "98765432109876543210"})
+	equal(t, len(typed.DecimalsOr("other", []string{"1"})), 1)
+
+	prices := typed.StringDecimal("prices")
+	equal(t, prices["a"], "1.10")
+	equal(t, prices["b"], "2")
+	equal(t, typed.StringBigInt("prices") == nil, true)
+	equal(t, typed.StringBigFloat("prices")["b"].Text('f', 0), "2")
+	equal(t, typed.StringBigInt("badMap") == nil, true)
+}
@@ -0,0 +1,112 @@
+// Command typedgen generates Go structs from sample JSON documents
+// or from a JSON Schema:
+//
+//	typedgen -type User users.json more_users.json > user.go
+//	typedgen -schema -type User -package api user.schema.json
+//
+// The shapes of every sample (a file can hold an object or an
+// array of objects) are merged: a field missing from some samples,
+// or sometimes null, is a pointer with omitempty.
+//
+// With -wrapper, it generates a wrapper around typed.Typed instead,
+// with a getter and a setter for each field:
+//
+//	typedgen -wrapper -schema -type Config config.schema.json
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/karlseguin/typed"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "typedgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("typedgen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	isSchema := flags.Bool("schema", false, "the file is a JSON Schema rather than sample documents")
+	pkg := flags.String("package", "main", "the package of the generated file")
+	name := flags.String("type", "Root", "the name of the generated type")
+	out := flags.String("o", "", "the file to write (stdout by default)")
+	wrapper := flags.Bool("wrapper", false, "generate a wrapper around typed.Typed rather than a struct")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: typedgen [flags] sample.json... | typedgen -schema [flags] schema.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		flags.Usage()
+		return errors.New("no input file")
+	}
+
+	schema, err := loadSchema(paths, *isSchema)
+	if err != nil {
+		return err
+	}
+	generate := generateStructs
+	if *wrapper {
+		generate = generateWrappers
+	}
+	source, err := generate(schema, *pkg, *name)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = stdout.Write(source)
+		return err
+	}
+	return ioutil.WriteFile(*out, source, 0644)
+}
+
+// Loads the schema file, or infers a schema from the sample files
+func loadSchema(paths []string, isSchema bool) (typed.Typed, error) {
+	if isSchema {
+		if len(paths) != 1 {
+			return nil, errors.New("-schema takes a single file")
+		}
+		schema, err := typed.JsonFile(paths[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", paths[0], err)
+		}
+		if _, err := typed.CompileSchema(schema); err != nil {
+			return nil, fmt.Errorf("%s: %w", paths[0], err)
+		}
+		return schema, nil
+	}
+
+	inference := typed.NewInference()
+	for _, path := range paths {
+		samples, err := loadSamples(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		inference.Add(samples...)
+	}
+	return inference.Schema(), nil
+}
+
+// Loads a file containing an object or an array of objects
+func loadSamples(path string) ([]typed.Typed, error) {
+	sample, err := typed.JsonFile(path)
+	if err == nil {
+		return []typed.Typed{sample}, nil
+	}
+	if samples, arrayErr := typed.JsonFileArray(path); arrayErr == nil {
+		return samples, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_RunSamples(t *testing.T) {
+	dir := t.TempDir()
+	one := write(t, dir, "one.json", `{"id": 1, "name": "leto", "server": {"host": "a", "port": 80}}`)
+	many := write(t, dir, "many.json", `[{"id": 2, "name": null, "server": {"host": "b"}}, {"id": 3, "name": "paul", "server": {"host": "c"}, "tags": ["a"]}]`)
+
+	var out bytes.Buffer
+	equal(t, run([]string{"-package", "users", "-type", "User", one, many}, &out, ioutil.Discard), nil)
+	equal(t, out.String(), `// Code generated by typedgen. DO NOT EDIT.
+
+package users
+
+type User struct {
+	ID     int64      `+"`json:\"id\"`"+`
+	Name   *string    `+"`json:\"name\"`"+`
+	Server UserServer `+"`json:\"server\"`"+`
+	Tags   []string   `+"`json:\"tags,omitempty\"`"+`
+}
+
+type UserServer struct {
+	Host string `+"`json:\"host\"`"+`
+	Port *int64 `+"`json:\"port,omitempty\"`"+`
+}
+`)
+}
+
+func Test_RunSchema(t *testing.T) {
+	dir := t.TempDir()
+	schema := write(t, dir, "schema.json", `{"type": "object", "required": ["port"], "properties": {"port": {"type": "integer"}}}`)
+	out := filepath.Join(dir, "config.go")
+
+	var stdout bytes.Buffer
+	equal(t, run([]string{"-schema", "-o", out, schema}, &stdout, ioutil.Discard), nil)
+	equal(t, stdout.Len(), 0)
+	source, _ := ioutil.ReadFile(out)
+	equal(t, string(source), `// Code generated by typedgen. DO NOT EDIT.
+
+package main
+
+type Root struct {
+	Port int64 `+"`json:\"port\"`"+`
+}
+`)
+}
+
+func Test_RunWrapper(t *testing.T) {
+	dir := t.TempDir()
+	sample := write(t, dir, "sample.json", `{"port": 80}`)
+
+	var out bytes.Buffer
+	equal(t, run([]string{"-wrapper", "-type", "Config", sample}, &out, ioutil.Discard), nil)
+	equal(t, strings.Contains(out.String(), "type Config struct {\n\ttyped.Typed\n}"), true)
+	equal(t, strings.Contains(out.String(), "func (c Config) SetPort(value int64) {"), true)
+}
+
+func Test_RunErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := write(t, dir, "invalid.json", `{"id": `)
+	schema := write(t, dir, "schema.json", `{"type": "text"}`)
+	valid := write(t, dir, "valid.json", `{"id": 1}`)
+
+	for expected, args := range map[string][]string{
+		"no input file":                 {},
+		"invalid.json: unexpected EOF":  {invalid},
+		"missing.json":                  {filepath.Join(dir, "missing.json")},
+		"-schema takes a single file":   {"-schema", schema, valid},
+		`invalid schema at "/type"`:     {"-schema", schema},
+		"flag provided but not defined": {"-unknown", valid},
+		"doesn't describe an object":    {"-schema", write(t, dir, "map.json", `{"type": "object"}`)},
+	} {
+		err := run(args, ioutil.Discard, ioutil.Discard)
+		if err == nil || strings.Contains(err.Error(), expected) == false {
+			t.Errorf("expected %v to fail with %s, got %v", args, expected, err)
+		}
+	}
+}
+
+func write(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Words which are written in upper case in Go identifiers
+var initialisms = map[string]bool{
+	"api": true, "ascii": true, "cpu": true, "css": true, "dns": true, "eof": true,
+	"html": true, "http": true, "https": true, "id": true, "ip": true, "json": true,
+	"sql": true, "ssh": true, "tcp": true, "tls": true, "ttl": true, "udp": true,
+	"ui": true, "uri": true, "url": true, "utf8": true, "uuid": true, "xml": true,
+}
+
+// Converts a JSON key (e.g. "user_id", "firstName", "content-type")
+// into an exported Go identifier ("UserID", "FirstName", "ContentType")
+func goName(key string) string {
+	words := strings.FieldsFunc(key, func(r rune) bool {
+		return unicode.IsLetter(r) == false && unicode.IsDigit(r) == false
+	})
+	var sb strings.Builder
+	for _, word := range words {
+		if initialisms[strings.ToLower(word)] {
+			sb.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	name := sb.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// Returns the singular of an English plural, good enough for
+// naming the elements of an array ("servers" -> "server")
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "ss") || strings.HasSuffix(name, "us"):
+		return name
+	case strings.HasSuffix(name, "s") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+	return name + "Item"
+}
+
+// Returns the name, or the name with a numeric suffix if it's
+// already used, and marks it as used
+func unique(used map[string]bool, name string) string {
+	name = available(used, name)
+	used[name] = true
+	return name
+}
+
+// Returns the name, or the name with a numeric suffix if it's
+// already used
+func available(used map[string]bool, name string) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	return candidate
+}
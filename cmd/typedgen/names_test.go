@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_GoName(t *testing.T) {
+	for key, expected := range map[string]string{
+		"name":         "Name",
+		"firstName":    "FirstName",
+		"user_id":      "UserID",
+		"content-type": "ContentType",
+		"api url":      "APIURL",
+		"$schema":      "Schema",
+		"2fa":          "X2fa",
+		"":             "X",
+		"-":            "X",
+		"été":          "Été",
+	} {
+		equal(t, goName(key), expected)
+	}
+}
+
+func Test_Singular(t *testing.T) {
+	for name, expected := range map[string]string{
+		"RootServers": "RootServer",
+		"RootEntries": "RootEntry",
+		"RootAddress": "RootAddress",
+		"RootStatus":  "RootStatus",
+		"RootData":    "RootDataItem",
+	} {
+		equal(t, singular(name), expected)
+	}
+}
+
+func Test_Unique(t *testing.T) {
+	used := make(map[string]bool)
+	equal(t, unique(used, "Server"), "Server")
+	equal(t, available(used, "Server"), "Server2")
+	equal(t, unique(used, "Server"), "Server2")
+	equal(t, unique(used, "Server"), "Server3")
+	equal(t, unique(used, "Host"), "Host")
+}
+
+func equal(t *testing.T, actual interface{}, expected interface{}) {
+	t.Helper()
+	if actual != expected {
+		t.Errorf("expected '%v' to equal '%v", actual, expected)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/karlseguin/typed"
+)
+
+// Generates Go structs from a JSON Schema
+type generator struct {
+	root  typed.Typed
+	decls []string
+	// the type names which are taken
+	names map[string]bool
+	// the name of each struct by its fields, so that objects
+	// with the same shape share a struct
+	bodies map[string]string
+	// the name of the $ref being generated, which is kept even if
+	// another object has the same shape
+	named string
+	// the type generated for each $ref
+	refs map[string]resolvedRef
+	// the accessor of each $ref, for wrappers
+	accessors map[string]accessor
+	// the $refs whose type is being generated, a field referencing
+	// one of them must be a pointer
+	pending map[string]bool
+	// the packages the declarations use
+	imports map[string]bool
+}
+
+type resolvedRef struct {
+	t        string
+	nullable bool
+}
+
+func newGenerator(schema typed.Typed) *generator {
+	return &generator{
+		root:      schema,
+		names:     make(map[string]bool),
+		bodies:    make(map[string]string),
+		refs:      make(map[string]resolvedRef),
+		accessors: make(map[string]accessor),
+		pending:   make(map[string]bool),
+		imports:   make(map[string]bool),
+	}
+}
+
+// Generates the source of a file containing the struct named
+// name, described by the schema, and the structs it's made of
+func generateStructs(schema typed.Typed, pkg string, name string) ([]byte, error) {
+	g := newGenerator(schema)
+	g.refs["#"] = resolvedRef{t: name}
+	g.pending["#"] = true
+	if g.goType(schema, name); len(g.decls) == 0 {
+		return nil, fmt.Errorf("the schema doesn't describe an object with properties")
+	}
+	return g.source(pkg)
+}
+
+// Returns the formatted source of the declarations
+func (g *generator) source(pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by typedgen. DO NOT EDIT.\n\n")
+	buf.WriteString("package " + pkg + "\n")
+	if len(g.imports) > 0 {
+		// the standard library first, like goimports
+		var std, others []string
+		for path := range g.imports {
+			if strings.Contains(strings.Split(path, "/")[0], ".") {
+				others = append(others, strconv.Quote(path))
+			} else {
+				std = append(std, strconv.Quote(path))
+			}
+		}
+		sort.Strings(std)
+		sort.Strings(others)
+		groups := strings.Join(std, "\n")
+		if std != nil && others != nil {
+			groups += "\n\n"
+		}
+		groups += strings.Join(others, "\n")
+		buf.WriteString("\nimport (\n" + groups + "\n)\n")
+	}
+	for _, decl := range g.decls {
+		// structs with the same shape as another leave an empty slot
+		if decl != "" {
+			buf.WriteString("\n" + decl)
+		}
+	}
+	return format.Source(buf.Bytes())
+}
+
+// Returns the Go type of a value described by the schema and
+// whether the value can be null. Objects with properties become
+// structs, named after name.
+func (g *generator) goType(schema typed.Typed, name string) (string, bool) {
+	if schema == nil {
+		return "interface{}", false
+	}
+	if ref, ok := schema.StringIf("$ref"); ok {
+		return g.refType(ref, name)
+	}
+
+	types, nullable := schemaTypes(schema)
+	if len(types) == 0 && schema.Object("properties") != nil {
+		types = []string{"object"}
+	}
+	if len(types) != 1 {
+		return "interface{}", false
+	}
+
+	switch types[0] {
+	case "string":
+		return "string", nullable
+	case "integer":
+		return "int64", nullable
+	case "number":
+		return "float64", nullable
+	case "boolean":
+		return "bool", nullable
+	case "array":
+		element, elementNullable := g.goType(schema.Object("items"), singular(name))
+		return "[]" + fieldType(element, elementNullable), nullable
+	case "object":
+		if len(schema.Object("properties")) > 0 {
+			return g.structType(schema, name), nullable
+		}
+		if additional := schema.Object("additionalProperties"); additional != nil {
+			value, valueNullable := g.goType(additional, singular(name))
+			return "map[string]" + fieldType(value, valueNullable), nullable
+		}
+		return "map[string]interface{}", nullable
+	}
+	return "interface{}", false
+}
+
+// Returns the type of the schema referenced by a local $ref
+// ("#/$defs/server"), named after the last segment of the pointer
+func (g *generator) refType(ref string, name string) (string, bool) {
+	if r, exists := g.refs[ref]; exists {
+		return r.t, r.nullable || g.pending[ref]
+	}
+	if i := strings.LastIndexByte(ref, '/'); i != -1 {
+		name = goName(ref[i+1:])
+	}
+	// a recursive reference, found while the type is being
+	// generated, uses the name the struct is about to get
+	name = available(g.names, name)
+	g.refs[ref] = resolvedRef{t: name}
+	g.pending[ref] = true
+	g.named = name
+	t, nullable := g.goType(g.root.ObjectPointer(strings.TrimPrefix(ref, "#")), name)
+	g.named = ""
+	delete(g.pending, ref)
+	g.refs[ref] = resolvedRef{t, nullable}
+	return t, nullable
+}
+
+// Declares a struct for the object's properties and returns its name
+func (g *generator) structType(schema typed.Typed, name string) string {
+	named := g.named == name
+	g.named = ""
+	name = unique(g.names, name)
+	// declared before the structs of its fields
+	index := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	required := make(map[string]bool)
+	for _, key := range schema.Strings("required") {
+		required[key] = true
+	}
+	properties := schema.Object("properties")
+	keys := properties.Keys()
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	fields := make(map[string]bool)
+	for _, key := range keys {
+		field := unique(fields, goName(key))
+		t, nullable := g.goType(properties.Object(key), name+goName(key))
+		tag := key
+		if required[key] == false {
+			nullable = true
+			tag += ",omitempty"
+		}
+		if description := properties.Object(key).String("description"); description != "" {
+			sb.WriteString(comment(description))
+		}
+		sb.WriteString(fmt.Sprintf("\t%s %s `json:%q`\n", field, fieldType(t, nullable), tag))
+	}
+	body := sb.String()
+	if existing, exists := g.bodies[body]; exists == false {
+		g.bodies[body] = name
+	} else if named == false {
+		delete(g.names, name)
+		return existing
+	}
+
+	var decl string
+	if description := schema.String("description"); description != "" {
+		decl = comment(description)
+	}
+	g.decls[index] = decl + "type " + name + " struct {\n" + body + "}\n"
+	return name
+}
+
+// Returns the types of the schema, without "null", and whether
+// "null" was one of them
+func schemaTypes(schema typed.Typed) ([]string, bool) {
+	types, ok := schema.StringsIf("type")
+	if ok == false {
+		if t, ok := schema.StringIf("type"); ok {
+			types = []string{t}
+		}
+	}
+	nonNull := make([]string, 0, len(types))
+	for _, t := range types {
+		if t != "null" {
+			nonNull = append(nonNull, t)
+		}
+	}
+	return nonNull, len(nonNull) != len(types)
+}
+
+// Returns the type of a field, a pointer if the value is optional
+// (unless the zero value of the type already is nil)
+func fieldType(t string, optional bool) string {
+	if optional == false || t == "interface{}" || strings.HasPrefix(t, "*") ||
+		strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") {
+		return t
+	}
+	return "*" + t
+}
+
+func comment(text string) string {
+	var sb strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		sb.WriteString("// " + strings.TrimSpace(line) + "\n")
+	}
+	return sb.String()
+}
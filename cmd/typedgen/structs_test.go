@@ -0,0 +1,152 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/karlseguin/typed"
+)
+
+func generate(t *testing.T, schema string) string {
+	t.Helper()
+	s, err := typed.JsonString(schema)
+	equal(t, err, nil)
+	source, err := generateStructs(s, "api", "Root")
+	equal(t, err, nil)
+	return string(source)
+}
+
+func Test_GenerateTypes(t *testing.T) {
+	equal(t, generate(t, `{"type": "object", "required": ["a", "b", "c", "d", "e", "f", "g", "h"], "properties": {
+		"a": {"type": "string"}, "b": {"type": "integer"}, "c": {"type": "number"}, "d": {"type": "boolean"},
+		"e": {"type": "array", "items": {"type": "string"}}, "f": {"type": "object"},
+		"g": {"type": "object", "additionalProperties": {"type": "integer"}}, "h": {"type": ["string", "integer"]},
+		"i": {}, "j": true, "k": {"type": "array"}
+	}}`), `// Code generated by typedgen. DO NOT EDIT.
+
+package api
+
+type Root struct {
+	A string                 `+"`json:\"a\"`"+`
+	B int64                  `+"`json:\"b\"`"+`
+	C float64                `+"`json:\"c\"`"+`
+	D bool                   `+"`json:\"d\"`"+`
+	E []string               `+"`json:\"e\"`"+`
+	F map[string]interface{} `+"`json:\"f\"`"+`
+	G map[string]int64       `+"`json:\"g\"`"+`
+	H interface{}            `+"`json:\"h\"`"+`
+	I interface{}            `+"`json:\"i,omitempty\"`"+`
+	J interface{}            `+"`json:\"j,omitempty\"`"+`
+	K []interface{}          `+"`json:\"k,omitempty\"`"+`
+}
+`)
+}
+
+func Test_GenerateOptionalAndNullable(t *testing.T) {
+	equal(t, generate(t, `{"properties": {
+		"required": {"type": "string"}, "nullable": {"type": ["string", "null"]}, "optional": {"type": "integer"},
+		"list": {"type": ["array", "null"], "items": {"type": ["integer", "null"]}}
+	}, "required": ["required", "nullable", "list"]}`), `// Code generated by typedgen. DO NOT EDIT.
+
+package api
+
+type Root struct {
+	List     []*int64 `+"`json:\"list\"`"+`
+	Nullable *string  `+"`json:\"nullable\"`"+`
+	Optional *int64   `+"`json:\"optional,omitempty\"`"+`
+	Required string   `+"`json:\"required\"`"+`
+}
+`)
+}
+
+func Test_GenerateNested(t *testing.T) {
+	equal(t, generate(t, `{"type": "object", "description": "The root.", "properties": {
+		"server": {"type": "object", "properties": {"host": {"type": "string", "description": "The host\nor IP"}}, "required": ["host"]},
+		"servers": {"type": "array", "items": {"type": "object", "properties": {"host": {"type": "string", "description": "The host\nor IP"}}, "required": ["host"]}},
+		"owner": {"type": "object", "properties": {"name": {"type": "string"}}}
+	}}`), `// Code generated by typedgen. DO NOT EDIT.
+
+package api
+
+// The root.
+type Root struct {
+	Owner   *RootOwner   `+"`json:\"owner,omitempty\"`"+`
+	Server  *RootServer  `+"`json:\"server,omitempty\"`"+`
+	Servers []RootServer `+"`json:\"servers,omitempty\"`"+`
+}
+
+type RootOwner struct {
+	Name *string `+"`json:\"name,omitempty\"`"+`
+}
+
+type RootServer struct {
+	// The host
+	// or IP
+	Host string `+"`json:\"host\"`"+`
+}
+`)
+}
+
+func Test_GenerateRefs(t *testing.T) {
+	equal(t, generate(t, `{"type": "object", "required": ["home"], "properties": {
+		"home": {"$ref": "#/$defs/address"}, "work": {"$ref": "#/$defs/address"},
+		"parent": {"$ref": "#"}, "tree": {"$ref": "#/$defs/node"}
+	}, "$defs": {
+		"address": {"type": "object", "properties": {"city": {"type": "string"}}},
+		"node": {"type": "object", "required": ["next", "children"], "properties": {
+			"next": {"$ref": "#/$defs/node"}, "children": {"type": "array", "items": {"$ref": "#/$defs/node"}}
+		}}
+	}}`), `// Code generated by typedgen. DO NOT EDIT.
+
+package api
+
+type Root struct {
+	Home   Address  `+"`json:\"home\"`"+`
+	Parent *Root    `+"`json:\"parent,omitempty\"`"+`
+	Tree   *Node    `+"`json:\"tree,omitempty\"`"+`
+	Work   *Address `+"`json:\"work,omitempty\"`"+`
+}
+
+type Address struct {
+	City *string `+"`json:\"city,omitempty\"`"+`
+}
+
+type Node struct {
+	Children []*Node `+"`json:\"children\"`"+`
+	Next     *Node   `+"`json:\"next\"`"+`
+}
+`)
+}
+
+// a $ref keeps its own struct, named after it, even if an object
+// with the same shape was generated before it
+func Test_GenerateRefSameShape(t *testing.T) {
+	equal(t, generate(t, `{"type": "object", "required": ["backup", "server"], "properties": {
+		"backup": {"type": "object", "properties": {"host": {"type": "string"}}},
+		"server": {"$ref": "#/$defs/server"}, "copy": {"type": "object", "properties": {"host": {"type": "string"}}}
+	}, "$defs": {
+		"server": {"type": "object", "properties": {"host": {"type": "string"}}}
+	}}`), `// Code generated by typedgen. DO NOT EDIT.
+
+package api
+
+type Root struct {
+	Backup RootBackup  `+"`json:\"backup\"`"+`
+	Copy   *RootBackup `+"`json:\"copy,omitempty\"`"+`
+	Server Server      `+"`json:\"server\"`"+`
+}
+
+type RootBackup struct {
+	Host *string `+"`json:\"host,omitempty\"`"+`
+}
+
+type Server struct {
+	Host *string `+"`json:\"host,omitempty\"`"+`
+}
+`)
+}
+
+func Test_GenerateNotAnObject(t *testing.T) {
+	s, _ := typed.JsonString(`{"type": "string"}`)
+	_, err := generateStructs(s, "api", "Root")
+	equal(t, err.Error(), "the schema doesn't describe an object with properties")
+}
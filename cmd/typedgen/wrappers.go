@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/karlseguin/typed"
+)
+
+// How a field is read and written through a wrapper
+type accessor struct {
+	// the Go type of the field
+	t string
+	// the typed.Typed accessor: "Int", "Strings", "Object", ...
+	method string
+	// the wrapper of an object ("Object") or of the elements
+	// of an array of objects ("Objects")
+	wrapper string
+}
+
+// Generates the source of a file containing a wrapper around
+// typed.Typed named name, with accessors for the properties of
+// the schema, and the wrappers of its nested objects
+func generateWrappers(schema typed.Typed, pkg string, name string) ([]byte, error) {
+	g := newGenerator(schema)
+	g.imports["github.com/karlseguin/typed"] = true
+	g.accessors["#"] = accessor{t: name, method: "Object", wrapper: name}
+	if g.accessor(schema, name); len(g.decls) == 0 {
+		return nil, fmt.Errorf("the schema doesn't describe an object with properties")
+	}
+	return g.source(pkg)
+}
+
+// Returns how a value described by the schema is accessed. Objects
+// with properties get a wrapper, named after name.
+func (g *generator) accessor(schema typed.Typed, name string) accessor {
+	if schema == nil {
+		return accessor{t: "interface{}", method: "Interface"}
+	}
+	if ref, ok := schema.StringIf("$ref"); ok {
+		return g.refAccessor(ref, name)
+	}
+
+	types, _ := schemaTypes(schema)
+	if len(types) == 0 && schema.Object("properties") != nil {
+		types = []string{"object"}
+	}
+	if len(types) != 1 {
+		return accessor{t: "interface{}", method: "Interface"}
+	}
+
+	switch types[0] {
+	case "string":
+		switch schema.String("format") {
+		case "date-time":
+			g.imports["time"] = true
+			return accessor{t: "time.Time", method: "Time"}
+		case "duration":
+			g.imports["time"] = true
+			return accessor{t: "time.Duration", method: "Duration"}
+		}
+		return accessor{t: "string", method: "String"}
+	case "integer":
+		return accessor{t: "int64", method: "Int64"}
+	case "number":
+		return accessor{t: "float64", method: "Float"}
+	case "boolean":
+		return accessor{t: "bool", method: "Bool"}
+	case "array":
+		element := g.accessor(schema.Object("items"), singular(name))
+		switch element.method {
+		case "String", "Float", "Bool", "Time", "Duration":
+			return accessor{t: "[]" + element.t, method: element.method + "s"}
+		case "Int64":
+			return accessor{t: "[]int64", method: "Ints64"}
+		case "Object":
+			return accessor{t: "[]" + element.t, method: "Objects", wrapper: element.wrapper}
+		}
+	case "object":
+		if len(schema.Object("properties")) > 0 {
+			wrapper := g.wrapperType(schema, name)
+			return accessor{t: wrapper, method: "Object", wrapper: wrapper}
+		}
+		return accessor{t: "typed.Typed", method: "Object"}
+	}
+	return accessor{t: "interface{}", method: "Interface"}
+}
+
+// Returns how a value described by the schema referenced by a local
+// $ref ("#/$defs/server") is accessed. Its wrapper is named after
+// the last segment of the pointer.
+func (g *generator) refAccessor(ref string, name string) accessor {
+	if a, exists := g.accessors[ref]; exists {
+		return a
+	}
+	if i := strings.LastIndexByte(ref, '/'); i != -1 {
+		name = goName(ref[i+1:])
+	}
+	// a recursive reference, found while the wrapper is being
+	// generated, uses the name the wrapper is about to get
+	name = available(g.names, name)
+	g.accessors[ref] = accessor{t: name, method: "Object", wrapper: name}
+	g.named = name
+	a := g.accessor(g.root.ObjectPointer(strings.TrimPrefix(ref, "#")), name)
+	g.named = ""
+	g.accessors[ref] = a
+	return a
+}
+
+// Declares a wrapper for the object's properties and returns its name
+func (g *generator) wrapperType(schema typed.Typed, name string) string {
+	named := g.named == name
+	g.named = ""
+	name = unique(g.names, name)
+	index := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	properties := schema.Object("properties")
+	keys := properties.Keys()
+	sort.Strings(keys)
+
+	// the methods are written with placeholders for the type and
+	// receiver so that objects with the same shape share a wrapper
+	var sb strings.Builder
+	methods := map[string]bool{"Typed": true}
+	for _, key := range keys {
+		property := properties.Object(key)
+		field := goName(key)
+		for i := 2; methods[field] || methods[field+"If"] || methods["Set"+field]; i++ {
+			field = goName(key) + strconv.Itoa(i)
+		}
+		methods[field], methods[field+"If"], methods["Set"+field] = true, true, true
+
+		a := g.accessor(property, name+goName(key))
+		sb.WriteString("\n")
+		if description := property.String("description"); description != "" {
+			sb.WriteString(comment(description))
+		}
+		writeAccessors(&sb, field, strconv.Quote(key), a, defaultValue(property, a))
+	}
+	body := sb.String()
+	if existing, exists := g.bodies[body]; exists == false {
+		g.bodies[body] = name
+	} else if named == false {
+		delete(g.names, name)
+		return existing
+	}
+
+	decl := "// " + name + " wraps a typed.Typed with accessors for its known fields.\n"
+	if description := schema.String("description"); description != "" {
+		decl += "//\n" + comment(description)
+	}
+	decl += "type " + name + " struct {\n\ttyped.Typed\n}\n"
+	receiver := strings.ToLower(name[:1])
+	g.decls[index] = decl + strings.NewReplacer("\x00T", name, "\x00r", receiver).Replace(body)
+	return name
+}
+
+// Writes the getter, the If getter and the setter of a field, with
+// placeholders for the wrapper type (\x00T) and the receiver (\x00r),
+// which can't appear in a quoted key or default. The Typed methods are
+// called through the embedded field since an accessor can shadow them
+// (a "set" property has a Set() getter)
+func writeAccessors(sb *strings.Builder, field string, key string, a accessor, d string) {
+	sig := func(format string, args ...interface{}) {
+		sb.WriteString("func (\x00r \x00T) " + fmt.Sprintf(format, args...) + "\n")
+	}
+	switch {
+	case a.method == "Object" && a.wrapper != "":
+		sig("%s() %s {\n\treturn %s{\x00r.Typed.Object(%s)}\n}\n", field, a.t, a.wrapper, key)
+		sig("%sIf() (%s, bool) {\n\tobject, ok := \x00r.Typed.ObjectIf(%s)\n\treturn %s{object}, ok\n}\n", field, a.t, key, a.wrapper)
+		sig("Set%s(value %s) {\n\t\x00r.Typed.Set(%s, value.Typed)\n}", field, a.t, key)
+	case a.method == "Object":
+		sig("%s() %s {\n\treturn \x00r.Typed.Object(%s)\n}\n", field, a.t, key)
+		sig("%sIf() (%s, bool) {\n\treturn \x00r.Typed.ObjectIf(%s)\n}\n", field, a.t, key)
+		sig("Set%s(value %s) {\n\t\x00r.Typed.Set(%s, value)\n}", field, a.t, key)
+	case a.method == "Objects":
+		sig("%s() %s {\n\twrapped, _ := \x00r.%sIf()\n\treturn wrapped\n}\n", field, a.t, field)
+		sig("%sIf() (%s, bool) {\n\tobjects, ok := \x00r.Typed.ObjectsIf(%s)\n\tif ok == false {\n\t\treturn nil, false\n\t}\n"+
+			"\twrapped := make(%s, len(objects))\n\tfor i, object := range objects {\n\t\twrapped[i] = %s{object}\n\t}\n\treturn wrapped, true\n}\n",
+			field, a.t, key, a.t, a.wrapper)
+		sig("Set%s(value %s) {\n\tobjects := make([]typed.Typed, len(value))\n\tfor i, v := range value {\n\t\tobjects[i] = v.Typed\n\t}\n\t\x00r.Typed.Set(%s, objects)\n}",
+			field, a.t, key)
+	default:
+		sig("%s() %s {\n\treturn \x00r.Typed.%sOr(%s, %s)\n}\n", field, a.t, a.method, key, d)
+		sig("%sIf() (%s, bool) {\n\treturn \x00r.Typed.%sIf(%s)\n}\n", field, a.t, a.method, key)
+		sig("Set%s(value %s) {\n\t\x00r.Typed.Set(%s, value)\n}", field, a.t, key)
+	}
+}
+
+// Returns the Go literal of the property's default, or of the
+// zero value of its type if it has none (or it can't be written)
+func defaultValue(property typed.Typed, a accessor) string {
+	value := property["default"]
+	switch a.method {
+	case "String":
+		if s, ok := value.(string); ok {
+			return strconv.Quote(s)
+		}
+		return `""`
+	case "Int64":
+		// a json.Number, or a float64 when the schema wasn't parsed
+		// with UseNumber, but not a string
+		if i, ok := property.WithPolicy(typed.Strict()).Int64If("default"); ok {
+			return strconv.FormatInt(i, 10)
+		}
+		return "0"
+	case "Float":
+		switch n := value.(type) {
+		case json.Number:
+			if _, err := n.Float64(); err == nil {
+				return string(n)
+			}
+		case float64:
+			return strconv.FormatFloat(n, 'g', -1, 64)
+		}
+		return "0"
+	case "Bool":
+		if b, ok := value.(bool); ok {
+			return strconv.FormatBool(b)
+		}
+		return "false"
+	case "Time":
+		return "time.Time{}"
+	case "Duration":
+		return "0"
+	}
+	return "nil"
+}
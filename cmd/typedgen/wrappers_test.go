@@ -0,0 +1,198 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karlseguin/typed"
+)
+
+func generateWrapper(t *testing.T, schema string) string {
+	t.Helper()
+	s, err := typed.JsonString(schema)
+	equal(t, err, nil)
+	source, err := generateWrappers(s, "api", "Config")
+	equal(t, err, nil)
+	return string(source)
+}
+
+func Test_WrapperScalars(t *testing.T) {
+	equal(t, generateWrapper(t, `{"type": "object", "description": "The configuration.", "properties": {
+		"port": {"type": "integer", "default": 8080, "description": "The port to listen on"},
+		"host": {"type": ["string", "null"]}
+	}}`), `// Code generated by typedgen. DO NOT EDIT.
+
+package api
+
+import (
+	"github.com/karlseguin/typed"
+)
+
+// Config wraps a typed.Typed with accessors for its known fields.
+//
+// The configuration.
+type Config struct {
+	typed.Typed
+}
+
+func (c Config) Host() string {
+	return c.Typed.StringOr("host", "")
+}
+
+func (c Config) HostIf() (string, bool) {
+	return c.Typed.StringIf("host")
+}
+
+func (c Config) SetHost(value string) {
+	c.Typed.Set("host", value)
+}
+
+// The port to listen on
+func (c Config) Port() int64 {
+	return c.Typed.Int64Or("port", 8080)
+}
+
+func (c Config) PortIf() (int64, bool) {
+	return c.Typed.Int64If("port")
+}
+
+func (c Config) SetPort(value int64) {
+	c.Typed.Set("port", value)
+}
+`)
+}
+
+func Test_WrapperAccessors(t *testing.T) {
+	source := generateWrapper(t, `{"properties": {
+		"ratio": {"type": "number", "default": 0.5}, "debug": {"type": "boolean", "default": true},
+		"name": {"type": "string", "default": "a \"b\""}, "timeout": {"type": "string", "format": "duration"},
+		"started": {"type": "string", "format": "date-time"}, "tags": {"type": "array", "items": {"type": "string"}},
+		"ids": {"type": "array", "items": {"type": "integer"}}, "meta": {"type": "object"}, "extra": {},
+		"matrix": {"type": "array", "items": {"type": "array"}}, "typed": {"type": "string"},
+		"size": {"type": "integer", "default": 1.5}, "port": {"type": "integer"}, "port_if": {"type": "integer"}
+	}}`)
+	for _, expected := range []string{
+		"import (\n\t\"time\"\n\n\t\"github.com/karlseguin/typed\"\n)",
+		`return c.Typed.FloatOr("ratio", 0.5)`,
+		`return c.Typed.BoolOr("debug", true)`,
+		`return c.Typed.StringOr("name", "a \"b\"")`,
+		"func (c Config) Timeout() time.Duration {\n\treturn c.Typed.DurationOr(\"timeout\", 0)",
+		"func (c Config) Started() time.Time {\n\treturn c.Typed.TimeOr(\"started\", time.Time{})",
+		"func (c Config) Tags() []string {\n\treturn c.Typed.StringsOr(\"tags\", nil)",
+		"func (c Config) IdsIf() ([]int64, bool) {\n\treturn c.Typed.Ints64If(\"ids\")",
+		"func (c Config) Meta() typed.Typed {\n\treturn c.Typed.Object(\"meta\")",
+		"func (c Config) SetMeta(value typed.Typed) {\n\tc.Typed.Set(\"meta\", value)",
+		"func (c Config) Extra() interface{} {\n\treturn c.Typed.InterfaceOr(\"extra\", nil)",
+		"func (c Config) Matrix() interface{} {\n\treturn c.Typed.InterfaceOr(\"matrix\", nil)",
+		// the embedded field and the methods of another field aren't redeclared
+		"func (c Config) Typed2() string {",
+		"func (c Config) PortIf2If() (int64, bool) {\n\treturn c.Typed.Int64If(\"port_if\")",
+		// not an integer
+		`return c.Typed.Int64Or("size", 0)`,
+	} {
+		if strings.Contains(source, expected) == false {
+			t.Errorf("expected the source to contain:\n%s\n\n%s", expected, source)
+		}
+	}
+	vet(t, source)
+}
+
+func Test_WrapperObjects(t *testing.T) {
+	source := generateWrapper(t, `{"properties": {
+		"server": {"$ref": "#/$defs/server"}, "servers": {"type": "array", "items": {"$ref": "#/$defs/server"}},
+		"backup": {"type": "object", "properties": {"host": {"type": "string"}}},
+		"parent": {"$ref": "#"}
+	}, "$defs": {"server": {"type": "object", "properties": {"host": {"type": "string"}}}}}`)
+	for _, expected := range []string{
+		"func (c Config) Backup() ConfigBackup {\n\treturn ConfigBackup{c.Typed.Object(\"backup\")}",
+		"func (c Config) Parent() Config {\n\treturn Config{c.Typed.Object(\"parent\")}",
+		"func (c Config) ServerIf() (Server, bool) {\n\tobject, ok := c.Typed.ObjectIf(\"server\")\n\treturn Server{object}, ok",
+		"func (c Config) SetServer(value Server) {\n\tc.Typed.Set(\"server\", value.Typed)",
+		"func (c Config) Servers() []Server {\n\twrapped, _ := c.ServersIf()\n\treturn wrapped",
+		"wrapped := make([]Server, len(objects))\n\tfor i, object := range objects {\n\t\twrapped[i] = Server{object}",
+		"func (c Config) SetServers(value []Server) {\n\tobjects := make([]typed.Typed, len(value))",
+		"type ConfigBackup struct {\n\ttyped.Typed\n}\n\nfunc (c ConfigBackup) Host() string {",
+		"type Server struct {\n\ttyped.Typed\n}\n\nfunc (s Server) Host() string {",
+	} {
+		if strings.Contains(source, expected) == false {
+			t.Errorf("expected the source to contain:\n%s\n\n%s", expected, source)
+		}
+	}
+	vet(t, source)
+}
+
+func Test_WrapperSameShape(t *testing.T) {
+	source := generateWrapper(t, `{"properties": {
+		"a": {"type": "object", "properties": {"host": {"type": "string"}}},
+		"b": {"type": "object", "properties": {"host": {"type": "string"}}}
+	}}`)
+	equal(t, strings.Count(source, "struct {"), 2)
+	equal(t, strings.Contains(source, "func (c Config) B() ConfigA {"), true)
+}
+
+func Test_WrapperNotAnObject(t *testing.T) {
+	s, _ := typed.JsonString(`{"type": "array"}`)
+	_, err := generateWrappers(s, "api", "Config")
+	equal(t, err.Error(), "the schema doesn't describe an object with properties")
+}
+
+// a schema decoded without UseNumber has float64 defaults
+func Test_WrapperFloatDefaults(t *testing.T) {
+	schema := typed.Typed{"properties": map[string]interface{}{
+		"port":  map[string]interface{}{"type": "integer", "default": 8080.0},
+		"size":  map[string]interface{}{"type": "integer", "default": 1.5},
+		"count": map[string]interface{}{"type": "integer", "default": "3"},
+		"ratio": map[string]interface{}{"type": "number", "default": 0.25},
+		"max":   map[string]interface{}{"type": "number", "default": 1e21},
+	}}
+	source, err := generateWrappers(schema, "api", "Config")
+	equal(t, err, nil)
+	for _, expected := range []string{
+		`return c.Typed.Int64Or("port", 8080)`,
+		`return c.Typed.Int64Or("size", 0)`,
+		`return c.Typed.Int64Or("count", 0)`,
+		`return c.Typed.FloatOr("ratio", 0.25)`,
+		`return c.Typed.FloatOr("max", 1e+21)`,
+	} {
+		if strings.Contains(string(source), expected) == false {
+			t.Errorf("expected the source to contain:\n%s\n\n%s", expected, source)
+		}
+	}
+	vet(t, string(source))
+}
+
+func Test_WrapperShadowedMethods(t *testing.T) {
+	source := generateWrapper(t, `{"properties": {
+		"set": {"type": "string"}, "object": {"type": "string"}, "objects_if": {"type": "string"},
+		"int_or": {"type": "integer"}, "keys": {"type": "boolean"}, "typed": {"type": "string"},
+		"port": {"type": "integer"}, "server": {"type": "object", "properties": {"host": {"type": "string"}}},
+		"servers": {"type": "array", "items": {"type": "object", "properties": {"host": {"type": "string"}}}}
+	}}`)
+	equal(t, strings.Contains(source, "func (c Config) Set() string {"), true)
+	equal(t, strings.Contains(source, "\tc.Typed.Set(\"port\", value)"), true)
+	vet(t, source)
+}
+
+// Type checks the generated source
+func vet(t *testing.T, source string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go isn't installed")
+	}
+	// within the module, so that the typed package resolves
+	dir, err := ioutil.TempDir(".", "_generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := exec.Command("go", "vet", "./"+filepath.Base(dir)).CombinedOutput(); err != nil {
+		t.Fatalf("the generated source doesn't compile: %s\n%s", output, source)
+	}
+}
@@ -0,0 +1,187 @@
+package typed
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// Every accessor converts a value through one of the functions below,
+// whatever its shape (Int, Ints, StringInt, IntPath, IntPointer, Decode
+// ...), so the same value converts identically no matter how it's read.
+// The source types are the ones produced by encoding/json (float64, or
+// json.Number with UseNumber, as JsonReader does), strings and any Go
+// bool, integer or float placed in the map directly.
+
+const maxUint = uint64(^uint(0))
+
+func toBool(value interface{}) (bool, bool) {
+	if n, ok := value.(bool); ok {
+		return n, true
+	}
+	return false, false
+}
+
+// Floats are truncated (2.1 is 2), but must be within the range of an
+// int. Strings must be integers
+func toInt(value interface{}) (int, bool) {
+	switch t := value.(type) {
+	case int:
+		return t, true
+	case string:
+		i, err := strconv.Atoi(t)
+		return i, err == nil
+	case float64:
+		return truncateFloat(t)
+	case float32:
+		return truncateFloat(float64(t))
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return int(i), int64(int(i)) == i
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return truncateFloat(f)
+	}
+	if i, ok := toInt64(value); ok {
+		return int(i), int64(int(i)) == i
+	}
+	if i, ok := toUint64(value); ok && i <= math.MaxInt64 {
+		return int(i), uint64(int(i)) == i
+	}
+	return 0, false
+}
+
+func truncateFloat(f float64) (int, bool) {
+	f = math.Trunc(f)
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 || float64(int(f)) != f {
+		return 0, false
+	}
+	return int(f), true
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch t := value.(type) {
+	case float64:
+		return t, true
+	case float32:
+		return float64(t), true
+	case string:
+		f, err := strconv.ParseFloat(t, 64)
+		return f, err == nil
+	case json.Number:
+		f, err := t.Float64()
+		return f, err == nil
+	}
+	if i, ok := toInt64(value); ok {
+		return float64(i), true
+	}
+	if i, ok := toUint64(value); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
+
+func toString(value interface{}) (string, bool) {
+	if n, ok := value.(string); ok {
+		return n, true
+	}
+	return "", false
+}
+
+// The elements of Ints64, which predates the fixed-width accessors
+// below and truncates floats like Int does, within the range of an int64
+func toTruncatedInt64(value interface{}) (int64, bool) {
+	if i, ok := toInt64(value); ok {
+		return i, true
+	}
+	var f float64
+	switch t := value.(type) {
+	case float64:
+		f = t
+	case float32:
+		f = float64(t)
+	case json.Number:
+		var err error
+		if f, err = t.Float64(); err != nil {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	f = math.Trunc(f)
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// The fixed-width integer accessors (Int8 through Int64 and Uint
+// through Uint64) don't truncate: a value with a fractional part
+// or one which doesn't fit in the type is rejected. json.Number
+// values are converted from their exact text, so 9007199254740993
+// doesn't go through a float64 and 1e3 is accepted as 1000.
+
+// Returns the value as an int64 if it's an integer within range
+func toInt64(value interface{}) (int64, bool) {
+	switch t := value.(type) {
+	case int:
+		return int64(t), true
+	case int8:
+		return int64(t), true
+	case int16:
+		return int64(t), true
+	case int32:
+		return int64(t), true
+	case int64:
+		return t, true
+	case string:
+		i, err := strconv.ParseInt(t, 10, 64)
+		return i, err == nil
+	}
+	if r, ok := toRat(value); ok && r.IsInt() && r.Num().IsInt64() {
+		return r.Num().Int64(), true
+	}
+	return 0, false
+}
+
+// Returns the value as a uint64 if it's a non-negative integer within range
+func toUint64(value interface{}) (uint64, bool) {
+	switch t := value.(type) {
+	case uint:
+		return uint64(t), true
+	case uint8:
+		return uint64(t), true
+	case uint16:
+		return uint64(t), true
+	case uint32:
+		return uint64(t), true
+	case uint64:
+		return t, true
+	case string:
+		i, err := strconv.ParseUint(t, 10, 64)
+		return i, err == nil
+	}
+	if r, ok := toRat(value); ok && r.IsInt() && r.Num().IsUint64() {
+		return r.Num().Uint64(), true
+	}
+	return 0, false
+}
+
+func toIntRange(value interface{}, min int64, max int64) (int64, bool) {
+	i, ok := toInt64(value)
+	if ok == false || i < min || i > max {
+		return 0, false
+	}
+	return i, true
+}
+
+func toUintRange(value interface{}, max uint64) (uint64, bool) {
+	i, ok := toUint64(value)
+	if ok == false || i > max {
+		return 0, false
+	}
+	return i, true
+}
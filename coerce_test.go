@@ -0,0 +1,201 @@
+package typed
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// each value is read as a scalar, as an element of an array and as a
+// value of an object, which must all agree
+func Test_CoerceInt(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected int
+		ok       bool
+	}{
+		{7, 7, true},
+		{int8(-7), -7, true},
+		{int16(7), 7, true},
+		{int32(7), 7, true},
+		{int64(7), 7, true},
+		{uint(7), 7, true},
+		{uint8(7), 7, true},
+		{uint16(7), 7, true},
+		{uint32(7), 7, true},
+		{uint64(7), 7, true},
+		{7.9, 7, true},
+		{float32(-7.9), -7, true},
+		{"7", 7, true},
+		{json.Number("7"), 7, true},
+		{json.Number("7.9"), 7, true},
+		{json.Number("7e2"), 700, true},
+		{uint64(1 << 63), 0, false},
+		{1e20, 0, false},
+		{json.Number("1e20"), 0, false},
+		{"7.9", 0, false},
+		{"nope", 0, false},
+		{true, 0, false},
+		{nil, 0, false},
+	}
+	for _, c := range cases {
+		assertCoerce(t, c.value, c.ok, func(typed Typed) (interface{}, bool) { return typed.IntIf("v") },
+			func(typed Typed) (interface{}, bool) {
+				n, ok := typed.IntsIf("v")
+				return n[0], ok
+			},
+			func(typed Typed) (interface{}, bool) {
+				m := typed.StringInt("v")
+				return m["k"], m != nil
+			})
+		if c.ok {
+			equal(t, New(build("v", c.value)).Int("v"), c.expected)
+		}
+		// Ints64 predates Int64 and converts its elements like Int
+		n, ok := New(build("v", []interface{}{c.value})).Ints64If("v")
+		equal(t, ok, c.ok)
+		if c.ok {
+			equal(t, n[0], int64(c.expected))
+		}
+	}
+}
+
+func Test_CoerceInt64(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected int64
+		ok       bool
+	}{
+		{7, 7, true},
+		{int8(-7), -7, true},
+		{uint64(7), 7, true},
+		{7.0, 7, true},
+		{float32(7), 7, true},
+		{"7", 7, true},
+		{json.Number("9007199254740993"), 9007199254740993, true},
+		{json.Number("7e2"), 700, true},
+		{7.9, 0, false},
+		{json.Number("7.9"), 0, false},
+		{uint64(1 << 63), 0, false},
+		{"nope", 0, false},
+		{true, 0, false},
+	}
+	for _, c := range cases {
+		assertCoerce(t, c.value, c.ok, func(typed Typed) (interface{}, bool) { return typed.Int64If("v") },
+			nil,
+			func(typed Typed) (interface{}, bool) {
+				m := typed.StringInt64("v")
+				return m["k"], m != nil
+			})
+		if c.ok {
+			equal(t, New(build("v", c.value)).Int64("v"), c.expected)
+		}
+	}
+}
+
+func Test_CoerceFloat(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected float64
+		ok       bool
+	}{
+		{7.5, 7.5, true},
+		{float32(7.5), 7.5, true},
+		{7, 7, true},
+		{int8(-7), -7, true},
+		{int64(7), 7, true},
+		{uint(7), 7, true},
+		{uint64(7), 7, true},
+		{"7.5", 7.5, true},
+		{json.Number("7.5"), 7.5, true},
+		{json.Number("75e-1"), 7.5, true},
+		{"nope", 0, false},
+		{true, 0, false},
+		{nil, 0, false},
+	}
+	for _, c := range cases {
+		assertCoerce(t, c.value, c.ok, func(typed Typed) (interface{}, bool) { return typed.FloatIf("v") },
+			func(typed Typed) (interface{}, bool) {
+				n, ok := typed.FloatsIf("v")
+				return n[0], ok
+			},
+			func(typed Typed) (interface{}, bool) {
+				m := typed.StringFloat("v")
+				return m["k"], m != nil
+			})
+		if c.ok {
+			equal(t, New(build("v", c.value)).Float("v"), c.expected)
+		}
+	}
+}
+
+func Test_CoerceBoolAndString(t *testing.T) {
+	for _, value := range []interface{}{true, "true", 1, json.Number("1"), nil} {
+		_, ok := toBool(value)
+		_, okSlice := New(build("v", []interface{}{value})).BoolsIf("v")
+		equal(t, okSlice, ok)
+	}
+	for _, value := range []interface{}{"a", 1, json.Number("1"), true, nil} {
+		_, ok := toString(value)
+		_, okSlice := New(build("v", []interface{}{value})).StringsIf("v")
+		equal(t, okSlice, ok)
+	}
+}
+
+// IntsIf and Ints64If used to return nil, false for an empty array
+func Test_CoerceEmptyArrays(t *testing.T) {
+	typed := New(build("empty", []interface{}{}))
+	for _, ok := range []bool{second(typed.IntsIf("empty")), second(typed.Ints64If("empty")), second(typed.FloatsIf("empty")), second(typed.StringsIf("empty")), second(typed.BoolsIf("empty"))} {
+		equal(t, ok, true)
+	}
+	ints, _ := typed.IntsIf("empty")
+	equal(t, ints != nil && len(ints) == 0, true)
+}
+
+// IntIf used to reject a json.Number with a fractional part while
+// truncating a float64, so the same document read differently
+// depending on how it was parsed
+func Test_CoerceNumberParsingAgrees(t *testing.T) {
+	document := `{"n": 3.7, "ns": [1.5, 2], "m": {"a": -2.5}}`
+	floats, _ := JsonString(document)
+	numbers, _ := JsonReader(strings.NewReader(document))
+	equal(t, numbers["n"], interface{}(json.Number("3.7")))
+	for _, typed := range []Typed{floats, numbers} {
+		equal(t, typed.Int("n"), 3)
+		equalList(t, typed.Ints("ns"), []int{1, 2})
+		equal(t, typed.StringInt("m")["a"], -2)
+	}
+}
+
+func Test_StringIntRejectsInvalidValues(t *testing.T) {
+	typed, _ := JsonString(`{"a": {"x": 1, "y": true}, "b": {"x": 1.5, "y": "2"}}`)
+	equal(t, typed.StringInt("a") == nil, true)
+	m := typed.StringInt("b")
+	equal(t, m["x"], 1)
+	equal(t, m["y"], 2)
+}
+
+type coerceRead func(typed Typed) (interface{}, bool)
+
+func assertCoerce(t *testing.T, value interface{}, ok bool, scalar coerceRead, slice coerceRead, object coerceRead) {
+	t.Helper()
+	s, sok := scalar(New(build("v", value)))
+	o, ook := object(New(build("v", map[string]interface{}{"k": value})))
+	equal(t, sok, ok)
+	equal(t, ook, ok)
+	if ok {
+		equal(t, o, s)
+	}
+	// a family without an exact slice accessor (Int64) passes nil
+	if slice != nil {
+		a, aok := slice(New(build("v", []interface{}{value})))
+		equal(t, aok, ok)
+		if ok {
+			equal(t, a, s)
+		}
+	}
+}
+
+func second(_ interface{}, ok bool) bool {
+	return ok
+}
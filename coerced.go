@@ -0,0 +1,2229 @@
+package typed
+
+import (
+	"math/big"
+	"time"
+)
+
+// A Typed which converts values according to a Policy (see
+// WithPolicy). Every accessor which converts a value (Bool, Int,
+// Float, String, Int8 ... Uint64, BigInt, BigFloat, Decimal, Time,
+// Duration, their slice and map forms, their path and pointer
+// variants, Decode and Collector) is overridden. Objects are
+// returned as a Coerced with the same policy, so that it applies
+// to nested values. The accessors which don't convert (Interface,
+// Map, Exists, ...) are Typed's. So are TimeLayout and TimeLayoutIf,
+// which only parse strings with the given layouts, and Validate,
+// whose rules check JSON types ("42" isn't an int): the policy
+// doesn't apply to them.
+type Coerced struct {
+	Typed
+	Policy Policy
+}
+
+func (c Coerced) wrap(t Typed) Coerced {
+	return Coerced{Typed: t, Policy: c.Policy}
+}
+
+func (c Coerced) wrapAll(ts []Typed) []Coerced {
+	if ts == nil {
+		return nil
+	}
+	n := make([]Coerced, len(ts))
+	for i, t := range ts {
+		n[i] = c.wrap(t)
+	}
+	return n
+}
+
+// Returns a boolean at the key, or false if it doesn't
+// exist or can't be converted
+func (c Coerced) Bool(key string) bool {
+	return c.BoolOr(key, false)
+}
+
+// Returns a boolean at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) BoolOr(key string, d bool) bool {
+	if value, exists := c.BoolIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a boolean at the key or panics
+func (c Coerced) BoolMust(key string) bool {
+	v, exists := c.BoolIf(key)
+	if exists == false {
+		panic(c.keyError(key, "boolean"))
+	}
+	return v
+}
+
+// Returns a bool or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) BoolE(key string) (bool, error) {
+	v, exists := c.BoolIf(key)
+	if exists == false {
+		return v, c.keyError(key, "boolean")
+	}
+	return v, nil
+}
+
+// Returns a boolean at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) BoolIf(key string) (bool, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return false, false
+	}
+	return c.Policy.toBool(value)
+}
+
+// Returns a int at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Int(key string) int {
+	return c.IntOr(key, 0)
+}
+
+// Returns a int at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) IntOr(key string, d int) int {
+	if value, exists := c.IntIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a int at the key or panics
+func (c Coerced) IntMust(key string) int {
+	v, exists := c.IntIf(key)
+	if exists == false {
+		panic(c.keyError(key, "int"))
+	}
+	return v
+}
+
+// Returns an int or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) IntE(key string) (int, error) {
+	v, exists := c.IntIf(key)
+	if exists == false {
+		return v, c.keyError(key, "int")
+	}
+	return v, nil
+}
+
+// Returns a int at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) IntIf(key string) (int, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toInt(value)
+}
+
+// Returns a float at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Float(key string) float64 {
+	return c.FloatOr(key, 0)
+}
+
+// Returns a float at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) FloatOr(key string, d float64) float64 {
+	if value, exists := c.FloatIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a float at the key or panics
+func (c Coerced) FloatMust(key string) float64 {
+	v, exists := c.FloatIf(key)
+	if exists == false {
+		panic(c.keyError(key, "float"))
+	}
+	return v
+}
+
+// Returns a float or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) FloatE(key string) (float64, error) {
+	v, exists := c.FloatIf(key)
+	if exists == false {
+		return v, c.keyError(key, "float")
+	}
+	return v, nil
+}
+
+// Returns a float at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) FloatIf(key string) (float64, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toFloat(value)
+}
+
+// Returns a string at the key, or an empty string if it doesn't
+// exist or can't be converted
+func (c Coerced) String(key string) string {
+	return c.StringOr(key, "")
+}
+
+// Returns a string at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) StringOr(key string, d string) string {
+	if value, exists := c.StringIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a string at the key or panics
+func (c Coerced) StringMust(key string) string {
+	v, exists := c.StringIf(key)
+	if exists == false {
+		panic(c.keyError(key, "string"))
+	}
+	return v
+}
+
+// Returns a string or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) StringE(key string) (string, error) {
+	v, exists := c.StringIf(key)
+	if exists == false {
+		return v, c.keyError(key, "string")
+	}
+	return v, nil
+}
+
+// Returns a string at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) StringIf(key string) (string, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return "", false
+	}
+	return c.Policy.toString(value)
+}
+
+// Returns a int8 at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Int8(key string) int8 {
+	return c.Int8Or(key, 0)
+}
+
+// Returns a int8 at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) Int8Or(key string, d int8) int8 {
+	if value, exists := c.Int8If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a int8 at the key or panics
+func (c Coerced) Int8Must(key string) int8 {
+	v, exists := c.Int8If(key)
+	if exists == false {
+		panic(c.keyError(key, "int8"))
+	}
+	return v
+}
+
+// Returns an int8 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) Int8E(key string) (int8, error) {
+	v, exists := c.Int8If(key)
+	if exists == false {
+		return v, c.keyError(key, "int8")
+	}
+	return v, nil
+}
+
+// Returns a int8 at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) Int8If(key string) (int8, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toInt8(value)
+}
+
+// Returns a int16 at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Int16(key string) int16 {
+	return c.Int16Or(key, 0)
+}
+
+// Returns a int16 at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) Int16Or(key string, d int16) int16 {
+	if value, exists := c.Int16If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a int16 at the key or panics
+func (c Coerced) Int16Must(key string) int16 {
+	v, exists := c.Int16If(key)
+	if exists == false {
+		panic(c.keyError(key, "int16"))
+	}
+	return v
+}
+
+// Returns an int16 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) Int16E(key string) (int16, error) {
+	v, exists := c.Int16If(key)
+	if exists == false {
+		return v, c.keyError(key, "int16")
+	}
+	return v, nil
+}
+
+// Returns a int16 at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) Int16If(key string) (int16, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toInt16(value)
+}
+
+// Returns a int32 at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Int32(key string) int32 {
+	return c.Int32Or(key, 0)
+}
+
+// Returns a int32 at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) Int32Or(key string, d int32) int32 {
+	if value, exists := c.Int32If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a int32 at the key or panics
+func (c Coerced) Int32Must(key string) int32 {
+	v, exists := c.Int32If(key)
+	if exists == false {
+		panic(c.keyError(key, "int32"))
+	}
+	return v
+}
+
+// Returns an int32 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) Int32E(key string) (int32, error) {
+	v, exists := c.Int32If(key)
+	if exists == false {
+		return v, c.keyError(key, "int32")
+	}
+	return v, nil
+}
+
+// Returns a int32 at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) Int32If(key string) (int32, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toInt32(value)
+}
+
+// Returns a int64 at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Int64(key string) int64 {
+	return c.Int64Or(key, 0)
+}
+
+// Returns a int64 at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) Int64Or(key string, d int64) int64 {
+	if value, exists := c.Int64If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a int64 at the key or panics
+func (c Coerced) Int64Must(key string) int64 {
+	v, exists := c.Int64If(key)
+	if exists == false {
+		panic(c.keyError(key, "int64"))
+	}
+	return v
+}
+
+// Returns an int64 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) Int64E(key string) (int64, error) {
+	v, exists := c.Int64If(key)
+	if exists == false {
+		return v, c.keyError(key, "int64")
+	}
+	return v, nil
+}
+
+// Returns a int64 at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) Int64If(key string) (int64, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toInt64(value)
+}
+
+// Returns a uint at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Uint(key string) uint {
+	return c.UintOr(key, 0)
+}
+
+// Returns a uint at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) UintOr(key string, d uint) uint {
+	if value, exists := c.UintIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a uint at the key or panics
+func (c Coerced) UintMust(key string) uint {
+	v, exists := c.UintIf(key)
+	if exists == false {
+		panic(c.keyError(key, "uint"))
+	}
+	return v
+}
+
+// Returns an uint or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) UintE(key string) (uint, error) {
+	v, exists := c.UintIf(key)
+	if exists == false {
+		return v, c.keyError(key, "uint")
+	}
+	return v, nil
+}
+
+// Returns a uint at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) UintIf(key string) (uint, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toUint(value)
+}
+
+// Returns a uint8 at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Uint8(key string) uint8 {
+	return c.Uint8Or(key, 0)
+}
+
+// Returns a uint8 at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) Uint8Or(key string, d uint8) uint8 {
+	if value, exists := c.Uint8If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a uint8 at the key or panics
+func (c Coerced) Uint8Must(key string) uint8 {
+	v, exists := c.Uint8If(key)
+	if exists == false {
+		panic(c.keyError(key, "uint8"))
+	}
+	return v
+}
+
+// Returns an uint8 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) Uint8E(key string) (uint8, error) {
+	v, exists := c.Uint8If(key)
+	if exists == false {
+		return v, c.keyError(key, "uint8")
+	}
+	return v, nil
+}
+
+// Returns a uint8 at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) Uint8If(key string) (uint8, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toUint8(value)
+}
+
+// Returns a uint16 at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Uint16(key string) uint16 {
+	return c.Uint16Or(key, 0)
+}
+
+// Returns a uint16 at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) Uint16Or(key string, d uint16) uint16 {
+	if value, exists := c.Uint16If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a uint16 at the key or panics
+func (c Coerced) Uint16Must(key string) uint16 {
+	v, exists := c.Uint16If(key)
+	if exists == false {
+		panic(c.keyError(key, "uint16"))
+	}
+	return v
+}
+
+// Returns an uint16 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) Uint16E(key string) (uint16, error) {
+	v, exists := c.Uint16If(key)
+	if exists == false {
+		return v, c.keyError(key, "uint16")
+	}
+	return v, nil
+}
+
+// Returns a uint16 at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) Uint16If(key string) (uint16, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toUint16(value)
+}
+
+// Returns a uint32 at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Uint32(key string) uint32 {
+	return c.Uint32Or(key, 0)
+}
+
+// Returns a uint32 at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) Uint32Or(key string, d uint32) uint32 {
+	if value, exists := c.Uint32If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a uint32 at the key or panics
+func (c Coerced) Uint32Must(key string) uint32 {
+	v, exists := c.Uint32If(key)
+	if exists == false {
+		panic(c.keyError(key, "uint32"))
+	}
+	return v
+}
+
+// Returns an uint32 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) Uint32E(key string) (uint32, error) {
+	v, exists := c.Uint32If(key)
+	if exists == false {
+		return v, c.keyError(key, "uint32")
+	}
+	return v, nil
+}
+
+// Returns a uint32 at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) Uint32If(key string) (uint32, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toUint32(value)
+}
+
+// Returns a uint64 at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Uint64(key string) uint64 {
+	return c.Uint64Or(key, 0)
+}
+
+// Returns a uint64 at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) Uint64Or(key string, d uint64) uint64 {
+	if value, exists := c.Uint64If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a uint64 at the key or panics
+func (c Coerced) Uint64Must(key string) uint64 {
+	v, exists := c.Uint64If(key)
+	if exists == false {
+		panic(c.keyError(key, "uint64"))
+	}
+	return v
+}
+
+// Returns an uint64 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) Uint64E(key string) (uint64, error) {
+	v, exists := c.Uint64If(key)
+	if exists == false {
+		return v, c.keyError(key, "uint64")
+	}
+	return v, nil
+}
+
+// Returns a uint64 at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) Uint64If(key string) (uint64, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toUint64(value)
+}
+
+// Returns a time at the key, or a zero time if it doesn't
+// exist or can't be converted
+func (c Coerced) Time(key string) time.Time {
+	return c.TimeOr(key, time.Time{})
+}
+
+// Returns a time at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) TimeOr(key string, d time.Time) time.Time {
+	if value, exists := c.TimeIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a time at the key or panics
+func (c Coerced) TimeMust(key string) time.Time {
+	v, exists := c.TimeIf(key)
+	if exists == false {
+		panic(c.keyError(key, "time.Time"))
+	}
+	return v
+}
+
+// Returns a time or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) TimeE(key string) (time.Time, error) {
+	v, exists := c.TimeIf(key)
+	if exists == false {
+		return v, c.keyError(key, "time.Time")
+	}
+	return v, nil
+}
+
+// Returns a time at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) TimeIf(key string) (time.Time, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return time.Time{}, false
+	}
+	return c.Policy.toTime(value)
+}
+
+// Returns a duration at the key, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) Duration(key string) time.Duration {
+	return c.DurationOr(key, 0)
+}
+
+// Returns a duration at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) DurationOr(key string, d time.Duration) time.Duration {
+	if value, exists := c.DurationIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a duration at the key or panics
+func (c Coerced) DurationMust(key string) time.Duration {
+	v, exists := c.DurationIf(key)
+	if exists == false {
+		panic(c.keyError(key, "time.Duration"))
+	}
+	return v
+}
+
+// Returns a duration or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) DurationE(key string) (time.Duration, error) {
+	v, exists := c.DurationIf(key)
+	if exists == false {
+		return v, c.keyError(key, "time.Duration")
+	}
+	return v, nil
+}
+
+// Returns a duration at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) DurationIf(key string) (time.Duration, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toDuration(value)
+}
+
+// Returns a big.Int at the key, or nil if it doesn't
+// exist or can't be converted
+func (c Coerced) BigInt(key string) *big.Int {
+	return c.BigIntOr(key, nil)
+}
+
+// Returns a big.Int at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) BigIntOr(key string, d *big.Int) *big.Int {
+	if value, exists := c.BigIntIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a big.Int at the key or panics
+func (c Coerced) BigIntMust(key string) *big.Int {
+	v, exists := c.BigIntIf(key)
+	if exists == false {
+		panic(c.keyError(key, "big.Int"))
+	}
+	return v
+}
+
+// Returns a big.Int or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) BigIntE(key string) (*big.Int, error) {
+	v, exists := c.BigIntIf(key)
+	if exists == false {
+		return v, c.keyError(key, "big.Int")
+	}
+	return v, nil
+}
+
+// Returns a big.Int at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) BigIntIf(key string) (*big.Int, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toBigInt(value)
+}
+
+// Returns a big.Float at the key, or nil if it doesn't
+// exist or can't be converted
+func (c Coerced) BigFloat(key string) *big.Float {
+	return c.BigFloatOr(key, nil)
+}
+
+// Returns a big.Float at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) BigFloatOr(key string, d *big.Float) *big.Float {
+	if value, exists := c.BigFloatIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a big.Float at the key or panics
+func (c Coerced) BigFloatMust(key string) *big.Float {
+	v, exists := c.BigFloatIf(key)
+	if exists == false {
+		panic(c.keyError(key, "big.Float"))
+	}
+	return v
+}
+
+// Returns a big.Float or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) BigFloatE(key string) (*big.Float, error) {
+	v, exists := c.BigFloatIf(key)
+	if exists == false {
+		return v, c.keyError(key, "big.Float")
+	}
+	return v, nil
+}
+
+// Returns a big.Float at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) BigFloatIf(key string) (*big.Float, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toBigFloat(value)
+}
+
+// Returns a decimal at the key, or an empty string if it doesn't
+// exist or can't be converted
+func (c Coerced) Decimal(key string) string {
+	return c.DecimalOr(key, "")
+}
+
+// Returns a decimal at the key, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) DecimalOr(key string, d string) string {
+	if value, exists := c.DecimalIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a decimal at the key or panics
+func (c Coerced) DecimalMust(key string) string {
+	v, exists := c.DecimalIf(key)
+	if exists == false {
+		panic(c.keyError(key, "decimal"))
+	}
+	return v
+}
+
+// Returns a decimal or an *Error if the key doesn't exist
+// or its value can't be converted
+func (c Coerced) DecimalE(key string) (string, error) {
+	v, exists := c.DecimalIf(key)
+	if exists == false {
+		return v, c.keyError(key, "decimal")
+	}
+	return v, nil
+}
+
+// Returns a decimal at the key and whether or not the
+// key existed and the value could be converted
+func (c Coerced) DecimalIf(key string) (string, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return "", false
+	}
+	return c.Policy.toDecimal(value)
+}
+
+// Returns a []bool at the key, or a nil slice
+func (c Coerced) Bools(key string) []bool {
+	return c.BoolsOr(key, nil)
+}
+
+// Returns a []bool at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) BoolsOr(key string, d []bool) []bool {
+	n, ok := c.BoolsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []bool + true if valid
+// Returns nil + false otherwise
+func (c Coerced) BoolsIf(key string) ([]bool, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toBools(value)
+}
+
+// Returns a []int at the key, or a nil slice
+func (c Coerced) Ints(key string) []int {
+	return c.IntsOr(key, nil)
+}
+
+// Returns a []int at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) IntsOr(key string, d []int) []int {
+	n, ok := c.IntsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []int + true if valid
+// Returns nil + false otherwise
+func (c Coerced) IntsIf(key string) ([]int, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toInts(value)
+}
+
+// Returns a []int64 at the key, or a nil slice
+func (c Coerced) Ints64(key string) []int64 {
+	return c.Ints64Or(key, nil)
+}
+
+// Returns a []int64 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Ints64Or(key string, d []int64) []int64 {
+	n, ok := c.Ints64If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []int64 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Ints64If(key string) ([]int64, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toInts64(value)
+}
+
+// Returns a []float64 at the key, or a nil slice
+func (c Coerced) Floats(key string) []float64 {
+	return c.FloatsOr(key, nil)
+}
+
+// Returns a []float64 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) FloatsOr(key string, d []float64) []float64 {
+	n, ok := c.FloatsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []float64 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) FloatsIf(key string) ([]float64, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toFloats(value)
+}
+
+// Returns a []string at the key, or a nil slice
+func (c Coerced) Strings(key string) []string {
+	return c.StringsOr(key, nil)
+}
+
+// Returns a []string at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) StringsOr(key string, d []string) []string {
+	n, ok := c.StringsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []string + true if valid
+// Returns nil + false otherwise
+func (c Coerced) StringsIf(key string) ([]string, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toStrings(value)
+}
+
+// Returns a []int8 at the key, or a nil slice
+func (c Coerced) Ints8(key string) []int8 {
+	return c.Ints8Or(key, nil)
+}
+
+// Returns a []int8 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Ints8Or(key string, d []int8) []int8 {
+	n, ok := c.Ints8If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []int8 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Ints8If(key string) ([]int8, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toInts8(value)
+}
+
+// Returns a []int16 at the key, or a nil slice
+func (c Coerced) Ints16(key string) []int16 {
+	return c.Ints16Or(key, nil)
+}
+
+// Returns a []int16 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Ints16Or(key string, d []int16) []int16 {
+	n, ok := c.Ints16If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []int16 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Ints16If(key string) ([]int16, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toInts16(value)
+}
+
+// Returns a []int32 at the key, or a nil slice
+func (c Coerced) Ints32(key string) []int32 {
+	return c.Ints32Or(key, nil)
+}
+
+// Returns a []int32 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Ints32Or(key string, d []int32) []int32 {
+	n, ok := c.Ints32If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []int32 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Ints32If(key string) ([]int32, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toInts32(value)
+}
+
+// Returns a []uint at the key, or a nil slice
+func (c Coerced) Uints(key string) []uint {
+	return c.UintsOr(key, nil)
+}
+
+// Returns a []uint at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) UintsOr(key string, d []uint) []uint {
+	n, ok := c.UintsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []uint + true if valid
+// Returns nil + false otherwise
+func (c Coerced) UintsIf(key string) ([]uint, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toUints(value)
+}
+
+// Returns a []uint8 at the key, or a nil slice
+func (c Coerced) Uints8(key string) []uint8 {
+	return c.Uints8Or(key, nil)
+}
+
+// Returns a []uint8 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Uints8Or(key string, d []uint8) []uint8 {
+	n, ok := c.Uints8If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []uint8 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Uints8If(key string) ([]uint8, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toUints8(value)
+}
+
+// Returns a []uint16 at the key, or a nil slice
+func (c Coerced) Uints16(key string) []uint16 {
+	return c.Uints16Or(key, nil)
+}
+
+// Returns a []uint16 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Uints16Or(key string, d []uint16) []uint16 {
+	n, ok := c.Uints16If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []uint16 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Uints16If(key string) ([]uint16, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toUints16(value)
+}
+
+// Returns a []uint32 at the key, or a nil slice
+func (c Coerced) Uints32(key string) []uint32 {
+	return c.Uints32Or(key, nil)
+}
+
+// Returns a []uint32 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Uints32Or(key string, d []uint32) []uint32 {
+	n, ok := c.Uints32If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []uint32 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Uints32If(key string) ([]uint32, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toUints32(value)
+}
+
+// Returns a []uint64 at the key, or a nil slice
+func (c Coerced) Uints64(key string) []uint64 {
+	return c.Uints64Or(key, nil)
+}
+
+// Returns a []uint64 at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Uints64Or(key string, d []uint64) []uint64 {
+	n, ok := c.Uints64If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []uint64 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Uints64If(key string) ([]uint64, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toUints64(value)
+}
+
+// Returns a []time.Time at the key, or a nil slice
+func (c Coerced) Times(key string) []time.Time {
+	return c.TimesOr(key, nil)
+}
+
+// Returns a []time.Time at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) TimesOr(key string, d []time.Time) []time.Time {
+	n, ok := c.TimesIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []time.Time + true if valid
+// Returns nil + false otherwise
+func (c Coerced) TimesIf(key string) ([]time.Time, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toTimes(value)
+}
+
+// Returns a []time.Duration at the key, or a nil slice
+func (c Coerced) Durations(key string) []time.Duration {
+	return c.DurationsOr(key, nil)
+}
+
+// Returns a []time.Duration at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) DurationsOr(key string, d []time.Duration) []time.Duration {
+	n, ok := c.DurationsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []time.Duration + true if valid
+// Returns nil + false otherwise
+func (c Coerced) DurationsIf(key string) ([]time.Duration, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toDurations(value)
+}
+
+// Returns a []*big.Int at the key, or a nil slice
+func (c Coerced) BigInts(key string) []*big.Int {
+	return c.BigIntsOr(key, nil)
+}
+
+// Returns a []*big.Int at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) BigIntsOr(key string, d []*big.Int) []*big.Int {
+	n, ok := c.BigIntsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []*big.Int + true if valid
+// Returns nil + false otherwise
+func (c Coerced) BigIntsIf(key string) ([]*big.Int, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toBigInts(value)
+}
+
+// Returns a []*big.Float at the key, or a nil slice
+func (c Coerced) BigFloats(key string) []*big.Float {
+	return c.BigFloatsOr(key, nil)
+}
+
+// Returns a []*big.Float at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) BigFloatsOr(key string, d []*big.Float) []*big.Float {
+	n, ok := c.BigFloatsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []*big.Float + true if valid
+// Returns nil + false otherwise
+func (c Coerced) BigFloatsIf(key string) ([]*big.Float, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toBigFloats(value)
+}
+
+// Returns a []string at the key, or a nil slice
+func (c Coerced) Decimals(key string) []string {
+	return c.DecimalsOr(key, nil)
+}
+
+// Returns a []string at the key, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) DecimalsOr(key string, d []string) []string {
+	n, ok := c.DecimalsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []string + true if valid
+// Returns nil + false otherwise
+func (c Coerced) DecimalsIf(key string) ([]string, bool) {
+	value, exists := c.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toDecimals(value)
+}
+
+// Returns an map[string]bool, or nil
+func (c Coerced) StringBool(key string) map[string]bool {
+	return c.StringBoolOr(key, nil)
+}
+
+// Returns an map[string]bool, or the specified map if the key
+// doesn't exist or one of the values can't be converted
+func (c Coerced) StringBoolOr(key string, d map[string]bool) map[string]bool {
+	m, ok := c.StringBoolIf(key)
+	if ok {
+		return m
+	}
+	return d
+}
+
+// Returns an map[string]bool + true if valid
+// Returns nil + false otherwise
+func (c Coerced) StringBoolIf(key string) (map[string]bool, bool) {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil, false
+	}
+	m := make(map[string]bool, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toBool(value)
+		if ok == false {
+			return nil, false
+		}
+		m[k] = v
+	}
+	return m, true
+}
+
+// Returns an map[string]int
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringInt(key string) map[string]int {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toInt(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]float64
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringFloat(key string) map[string]float64 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]float64, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toFloat(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]string, or nil
+func (c Coerced) StringString(key string) map[string]string {
+	return c.StringStringOr(key, nil)
+}
+
+// Returns an map[string]string, or the specified map if the key
+// doesn't exist or one of the values can't be converted
+func (c Coerced) StringStringOr(key string, d map[string]string) map[string]string {
+	m, ok := c.StringStringIf(key)
+	if ok {
+		return m
+	}
+	return d
+}
+
+// Returns an map[string]string + true if valid
+// Returns nil + false otherwise
+func (c Coerced) StringStringIf(key string) (map[string]string, bool) {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil, false
+	}
+	m := make(map[string]string, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toString(value)
+		if ok == false {
+			return nil, false
+		}
+		m[k] = v
+	}
+	return m, true
+}
+
+// Returns an map[string]int8
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringInt8(key string) map[string]int8 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int8, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toInt8(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]int16
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringInt16(key string) map[string]int16 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int16, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toInt16(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]int32
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringInt32(key string) map[string]int32 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int32, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toInt32(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]int64
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringInt64(key string) map[string]int64 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int64, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toInt64(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]uint
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringUint(key string) map[string]uint {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toUint(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]uint8
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringUint8(key string) map[string]uint8 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint8, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toUint8(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]uint16
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringUint16(key string) map[string]uint16 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint16, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toUint16(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]uint32
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringUint32(key string) map[string]uint32 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint32, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toUint32(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]uint64
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringUint64(key string) map[string]uint64 {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint64, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toUint64(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]time.Time
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringTime(key string) map[string]time.Time {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]time.Time, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toTime(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]*big.Int
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringBigInt(key string) map[string]*big.Int {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]*big.Int, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toBigInt(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]*big.Float
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringBigFloat(key string) map[string]*big.Float {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]*big.Float, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toBigFloat(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns an map[string]string
+// (returns nil if one of the values can't be converted)
+func (c Coerced) StringDecimal(key string) map[string]string {
+	raw, ok := c.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]string, len(raw))
+	for k, value := range raw {
+		v, ok := c.Policy.toDecimal(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// Returns the object at the key as a Coerced (see Typed.Object)
+func (c Coerced) Object(key string) Coerced {
+	return c.wrap(c.Typed.Object(key))
+}
+
+// Returns the object at the key as a Coerced, or the specified default
+func (c Coerced) ObjectOr(key string, d map[string]interface{}) Coerced {
+	return c.wrap(c.Typed.ObjectOr(key, d))
+}
+
+// Returns the object at the key as a Coerced or panics
+func (c Coerced) ObjectMust(key string) Coerced {
+	return c.wrap(c.Typed.ObjectMust(key))
+}
+
+// Returns the object at the key as a Coerced or an *Error
+func (c Coerced) ObjectE(key string) (Coerced, error) {
+	o, err := c.Typed.ObjectE(key)
+	return c.wrap(o), err
+}
+
+// Returns the object at the key as a Coerced and whether
+// or not the key existed and the value was an object
+func (c Coerced) ObjectIf(key string) (Coerced, bool) {
+	o, ok := c.Typed.ObjectIf(key)
+	return c.wrap(o), ok
+}
+
+// Returns the objects at the key as a Coerced, or a nil slice
+func (c Coerced) Objects(key string) []Coerced {
+	return c.wrapAll(c.Typed.Objects(key))
+}
+
+// Returns the objects at the key as a Coerced and whether
+// or not the key existed and the value was an array
+func (c Coerced) ObjectsIf(key string) ([]Coerced, bool) {
+	o, ok := c.Typed.ObjectsIf(key)
+	return c.wrapAll(o), ok
+}
+
+// Returns the objects at the key as a Coerced or an *Error
+func (c Coerced) ObjectsE(key string) ([]Coerced, error) {
+	o, err := c.Typed.ObjectsE(key)
+	return c.wrapAll(o), err
+}
+
+// Returns the objects at the key as a Coerced or panics
+func (c Coerced) ObjectsMust(key string) []Coerced {
+	return c.wrapAll(c.Typed.ObjectsMust(key))
+}
+
+// Returns the object at the path as a Coerced (see Typed.ObjectPath)
+func (c Coerced) ObjectPath(path string) Coerced {
+	return c.wrap(c.Typed.ObjectPath(path))
+}
+
+// Returns the object at the path as a Coerced, or the specified default
+func (c Coerced) ObjectPathOr(path string, d map[string]interface{}) Coerced {
+	return c.wrap(c.Typed.ObjectPathOr(path, d))
+}
+
+// Returns the object at the path as a Coerced or panics
+func (c Coerced) ObjectPathMust(path string) Coerced {
+	return c.wrap(c.Typed.ObjectPathMust(path))
+}
+
+// Returns the object at the path as a Coerced and whether
+// or not the path existed and the value was an object
+func (c Coerced) ObjectPathIf(path string) (Coerced, bool) {
+	o, ok := c.Typed.ObjectPathIf(path)
+	return c.wrap(o), ok
+}
+
+// Returns the objects at the path as a Coerced, or a nil slice
+func (c Coerced) ObjectsPath(path string) []Coerced {
+	return c.wrapAll(c.Typed.ObjectsPath(path))
+}
+
+// Returns the objects at the path as a Coerced and whether
+// or not the path existed and the value was an array
+func (c Coerced) ObjectsPathIf(path string) ([]Coerced, bool) {
+	o, ok := c.Typed.ObjectsPathIf(path)
+	return c.wrapAll(o), ok
+}
+
+// Returns the objects at the path as a Coerced or panics
+func (c Coerced) ObjectsPathMust(path string) []Coerced {
+	return c.wrapAll(c.Typed.ObjectsPathMust(path))
+}
+
+// Returns the object at the pointer as a Coerced (see Typed.ObjectPointer)
+func (c Coerced) ObjectPointer(pointer string) Coerced {
+	return c.wrap(c.Typed.ObjectPointer(pointer))
+}
+
+// Returns the object at the pointer as a Coerced, or the specified default
+func (c Coerced) ObjectPointerOr(pointer string, d map[string]interface{}) Coerced {
+	return c.wrap(c.Typed.ObjectPointerOr(pointer, d))
+}
+
+// Returns the object at the pointer as a Coerced or panics
+func (c Coerced) ObjectPointerMust(pointer string) Coerced {
+	return c.wrap(c.Typed.ObjectPointerMust(pointer))
+}
+
+// Returns the object at the pointer as a Coerced and whether
+// or not the pointer existed and the value was an object
+func (c Coerced) ObjectPointerIf(pointer string) (Coerced, bool) {
+	o, ok := c.Typed.ObjectPointerIf(pointer)
+	return c.wrap(o), ok
+}
+
+// Returns the objects at the pointer as a Coerced, or a nil slice
+func (c Coerced) ObjectsPointer(pointer string) []Coerced {
+	return c.wrapAll(c.Typed.ObjectsPointer(pointer))
+}
+
+// Returns the objects at the pointer as a Coerced and whether
+// or not the pointer existed and the value was an array
+func (c Coerced) ObjectsPointerIf(pointer string) ([]Coerced, bool) {
+	o, ok := c.Typed.ObjectsPointerIf(pointer)
+	return c.wrapAll(o), ok
+}
+
+// Returns the objects at the pointer as a Coerced or panics
+func (c Coerced) ObjectsPointerMust(pointer string) []Coerced {
+	return c.wrapAll(c.Typed.ObjectsPointerMust(pointer))
+}
+
+// Returns an map[string]Coerced, or nil
+func (c Coerced) StringObject(key string) map[string]Coerced {
+	return c.StringObjectOr(key, nil)
+}
+
+// Returns an map[string]Coerced, or the specified map if the
+// key doesn't exist or one of the values isn't an object
+func (c Coerced) StringObjectOr(key string, d map[string]Coerced) map[string]Coerced {
+	m, ok := c.StringObjectIf(key)
+	if ok {
+		return m
+	}
+	return d
+}
+
+// Returns an map[string]Coerced + true if valid
+// Returns nil + false otherwise
+func (c Coerced) StringObjectIf(key string) (map[string]Coerced, bool) {
+	objects, ok := c.Typed.StringObjectIf(key)
+	if ok == false {
+		return nil, false
+	}
+	m := make(map[string]Coerced, len(objects))
+	for k, o := range objects {
+		m[k] = c.wrap(o)
+	}
+	return m, true
+}
+
+// Returns a boolean at the path, or false if it doesn't
+// exist or can't be converted
+func (c Coerced) BoolPath(path string) bool {
+	return c.BoolPathOr(path, false)
+}
+
+// Returns a boolean at the path, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) BoolPathOr(path string, d bool) bool {
+	if value, exists := c.BoolPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a boolean at the path or panics
+func (c Coerced) BoolPathMust(path string) bool {
+	v, exists := c.BoolPathIf(path)
+	if exists == false {
+		panic(c.pathError(path, "boolean"))
+	}
+	return v
+}
+
+// Returns a boolean at the path and whether or not the
+// path existed and the value could be converted
+func (c Coerced) BoolPathIf(path string) (bool, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return false, false
+	}
+	return c.Policy.toBool(value)
+}
+
+// Returns a int at the path, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) IntPath(path string) int {
+	return c.IntPathOr(path, 0)
+}
+
+// Returns a int at the path, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) IntPathOr(path string, d int) int {
+	if value, exists := c.IntPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a int at the path or panics
+func (c Coerced) IntPathMust(path string) int {
+	v, exists := c.IntPathIf(path)
+	if exists == false {
+		panic(c.pathError(path, "int"))
+	}
+	return v
+}
+
+// Returns a int at the path and whether or not the
+// path existed and the value could be converted
+func (c Coerced) IntPathIf(path string) (int, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toInt(value)
+}
+
+// Returns a float at the path, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) FloatPath(path string) float64 {
+	return c.FloatPathOr(path, 0)
+}
+
+// Returns a float at the path, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) FloatPathOr(path string, d float64) float64 {
+	if value, exists := c.FloatPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a float at the path or panics
+func (c Coerced) FloatPathMust(path string) float64 {
+	v, exists := c.FloatPathIf(path)
+	if exists == false {
+		panic(c.pathError(path, "float"))
+	}
+	return v
+}
+
+// Returns a float at the path and whether or not the
+// path existed and the value could be converted
+func (c Coerced) FloatPathIf(path string) (float64, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toFloat(value)
+}
+
+// Returns a string at the path, or an empty string if it doesn't
+// exist or can't be converted
+func (c Coerced) StringPath(path string) string {
+	return c.StringPathOr(path, "")
+}
+
+// Returns a string at the path, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) StringPathOr(path string, d string) string {
+	if value, exists := c.StringPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a string at the path or panics
+func (c Coerced) StringPathMust(path string) string {
+	v, exists := c.StringPathIf(path)
+	if exists == false {
+		panic(c.pathError(path, "string"))
+	}
+	return v
+}
+
+// Returns a string at the path and whether or not the
+// path existed and the value could be converted
+func (c Coerced) StringPathIf(path string) (string, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return "", false
+	}
+	return c.Policy.toString(value)
+}
+
+// Returns a time at the path, or a zero time if it doesn't
+// exist or can't be converted
+func (c Coerced) TimePath(path string) time.Time {
+	return c.TimePathOr(path, time.Time{})
+}
+
+// Returns a time at the path, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) TimePathOr(path string, d time.Time) time.Time {
+	if value, exists := c.TimePathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a time at the path or panics
+func (c Coerced) TimePathMust(path string) time.Time {
+	v, exists := c.TimePathIf(path)
+	if exists == false {
+		panic(c.pathError(path, "time.Time"))
+	}
+	return v
+}
+
+// Returns a time at the path and whether or not the
+// path existed and the value could be converted
+func (c Coerced) TimePathIf(path string) (time.Time, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return time.Time{}, false
+	}
+	return c.Policy.toTime(value)
+}
+
+// Returns a boolean at the pointer, or false if it doesn't
+// exist or can't be converted
+func (c Coerced) BoolPointer(pointer string) bool {
+	return c.BoolPointerOr(pointer, false)
+}
+
+// Returns a boolean at the pointer, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) BoolPointerOr(pointer string, d bool) bool {
+	if value, exists := c.BoolPointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a boolean at the pointer or panics
+func (c Coerced) BoolPointerMust(pointer string) bool {
+	v, exists := c.BoolPointerIf(pointer)
+	if exists == false {
+		panic(c.pointerError(pointer, "boolean"))
+	}
+	return v
+}
+
+// Returns a boolean at the pointer and whether or not the
+// pointer existed and the value could be converted
+func (c Coerced) BoolPointerIf(pointer string) (bool, bool) {
+	value, exists := c.resolve(pointer)
+	if exists == false {
+		return false, false
+	}
+	return c.Policy.toBool(value)
+}
+
+// Returns a int at the pointer, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) IntPointer(pointer string) int {
+	return c.IntPointerOr(pointer, 0)
+}
+
+// Returns a int at the pointer, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) IntPointerOr(pointer string, d int) int {
+	if value, exists := c.IntPointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a int at the pointer or panics
+func (c Coerced) IntPointerMust(pointer string) int {
+	v, exists := c.IntPointerIf(pointer)
+	if exists == false {
+		panic(c.pointerError(pointer, "int"))
+	}
+	return v
+}
+
+// Returns a int at the pointer and whether or not the
+// pointer existed and the value could be converted
+func (c Coerced) IntPointerIf(pointer string) (int, bool) {
+	value, exists := c.resolve(pointer)
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toInt(value)
+}
+
+// Returns a float at the pointer, or 0 if it doesn't
+// exist or can't be converted
+func (c Coerced) FloatPointer(pointer string) float64 {
+	return c.FloatPointerOr(pointer, 0)
+}
+
+// Returns a float at the pointer, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) FloatPointerOr(pointer string, d float64) float64 {
+	if value, exists := c.FloatPointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a float at the pointer or panics
+func (c Coerced) FloatPointerMust(pointer string) float64 {
+	v, exists := c.FloatPointerIf(pointer)
+	if exists == false {
+		panic(c.pointerError(pointer, "float"))
+	}
+	return v
+}
+
+// Returns a float at the pointer and whether or not the
+// pointer existed and the value could be converted
+func (c Coerced) FloatPointerIf(pointer string) (float64, bool) {
+	value, exists := c.resolve(pointer)
+	if exists == false {
+		return 0, false
+	}
+	return c.Policy.toFloat(value)
+}
+
+// Returns a string at the pointer, or an empty string if it doesn't
+// exist or can't be converted
+func (c Coerced) StringPointer(pointer string) string {
+	return c.StringPointerOr(pointer, "")
+}
+
+// Returns a string at the pointer, or the specified
+// value if it doesn't exist or can't be converted
+func (c Coerced) StringPointerOr(pointer string, d string) string {
+	if value, exists := c.StringPointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a string at the pointer or panics
+func (c Coerced) StringPointerMust(pointer string) string {
+	v, exists := c.StringPointerIf(pointer)
+	if exists == false {
+		panic(c.pointerError(pointer, "string"))
+	}
+	return v
+}
+
+// Returns a string at the pointer and whether or not the
+// pointer existed and the value could be converted
+func (c Coerced) StringPointerIf(pointer string) (string, bool) {
+	value, exists := c.resolve(pointer)
+	if exists == false {
+		return "", false
+	}
+	return c.Policy.toString(value)
+}
+
+// Returns a []bool at the path, or a nil slice
+func (c Coerced) BoolsPath(path string) []bool {
+	return c.BoolsPathOr(path, nil)
+}
+
+// Returns a []bool at the path, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) BoolsPathOr(path string, d []bool) []bool {
+	n, ok := c.BoolsPathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []bool + true if valid
+// Returns nil + false otherwise
+func (c Coerced) BoolsPathIf(path string) ([]bool, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toBools(value)
+}
+
+// Returns a []int at the path, or a nil slice
+func (c Coerced) IntsPath(path string) []int {
+	return c.IntsPathOr(path, nil)
+}
+
+// Returns a []int at the path, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) IntsPathOr(path string, d []int) []int {
+	n, ok := c.IntsPathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []int + true if valid
+// Returns nil + false otherwise
+func (c Coerced) IntsPathIf(path string) ([]int, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toInts(value)
+}
+
+// Returns a []int64 at the path, or a nil slice
+func (c Coerced) Ints64Path(path string) []int64 {
+	return c.Ints64PathOr(path, nil)
+}
+
+// Returns a []int64 at the path, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) Ints64PathOr(path string, d []int64) []int64 {
+	n, ok := c.Ints64PathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []int64 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) Ints64PathIf(path string) ([]int64, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toInts64(value)
+}
+
+// Returns a []float64 at the path, or a nil slice
+func (c Coerced) FloatsPath(path string) []float64 {
+	return c.FloatsPathOr(path, nil)
+}
+
+// Returns a []float64 at the path, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) FloatsPathOr(path string, d []float64) []float64 {
+	n, ok := c.FloatsPathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []float64 + true if valid
+// Returns nil + false otherwise
+func (c Coerced) FloatsPathIf(path string) ([]float64, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toFloats(value)
+}
+
+// Returns a []string at the path, or a nil slice
+func (c Coerced) StringsPath(path string) []string {
+	return c.StringsPathOr(path, nil)
+}
+
+// Returns a []string at the path, or the specified slice if it
+// doesn't exist or one of its values can't be converted
+func (c Coerced) StringsPathOr(path string, d []string) []string {
+	n, ok := c.StringsPathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a []string + true if valid
+// Returns nil + false otherwise
+func (c Coerced) StringsPathIf(path string) ([]string, bool) {
+	value, exists := c.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return c.Policy.toStrings(value)
+}
+
+// Decodes the value at the key into out, converting values
+// according to the policy (see Typed.Decode)
+func (c Coerced) Decode(key string, out interface{}) error {
+	if len(key) == 0 {
+		return c.DecodeAll(out)
+	}
+	value, exists := c.Typed[key]
+	if exists == false {
+		return KeyNotFound
+	}
+	return c.Policy.decodeInto(key, value, out)
+}
+
+// Decodes the whole document into out, converting values
+// according to the policy (see Typed.Decode)
+func (c Coerced) DecodeAll(out interface{}) error {
+	return c.Policy.decodeInto("", c.Typed, out)
+}
+
+// Returns a Collector which reads c, converting values
+// according to the policy
+func (c Coerced) Collector() *Collector {
+	return &Collector{t: c, errors: new(Errors)}
+}
@@ -0,0 +1,498 @@
+package typed
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reads a whole payload, recording every missing or invalid value
+// rather than stopping at the first one. X(key) reads a required
+// value and XOr(key, d) an optional one: a missing optional value
+// isn't an error but an invalid one is. Once everything has been
+// read, Err returns all the problems at once.
+//
+//	c := typed.Collector()
+//	name := c.String("name")
+//	port := c.Object("server").IntOr("port", 80)
+//	if err := c.Err(); err != nil {
+//		...
+//	}
+type Collector struct {
+	t      Coerced
+	path   string
+	errors *Errors
+}
+
+// A list of problems recorded by a Collector
+type Errors []*Error
+
+func (e Errors) Error() string {
+	problems := make([]string, len(e))
+	for i, err := range e {
+		if err.Actual == "missing" {
+			problems[i] = err.Key + " is required"
+		} else {
+			problems[i] = err.Error() + ", got " + err.Actual
+		}
+	}
+	if len(e) == 1 {
+		return problems[0]
+	}
+	return strconv.Itoa(len(e)) + " errors: " + strings.Join(problems, "; ")
+}
+
+// Returns a Collector which reads t
+func (t Typed) Collector() *Collector {
+	return t.WithPolicy(standard).Collector()
+}
+
+// Returns the problems recorded so far or nil if there are none
+func (c *Collector) Err() error {
+	if len(*c.errors) == 0 {
+		return nil
+	}
+	return *c.errors
+}
+
+// Returns the problems recorded so far
+func (c *Collector) Errors() Errors {
+	return *c.errors
+}
+
+func (c *Collector) record(key string, expected string, value interface{}, exists bool) {
+	*c.errors = append(*c.errors, newError(joinPath(c.path, key), expected, value, exists))
+}
+
+func (c *Collector) child(path string, t Coerced) *Collector {
+	return &Collector{t: t, path: path, errors: c.errors}
+}
+
+// The values of a missing or invalid object aren't
+// recorded, its own problem is enough
+func detached(path string) *Collector {
+	return &Collector{path: path, errors: new(Errors)}
+}
+
+// Returns the object at the key as a Collector which records
+// its problems (prefixed with the key) with its parent's. A
+// missing or invalid object is recorded and an empty Collector,
+// which doesn't record anything, is returned
+func (c *Collector) Object(key string) *Collector {
+	o, exists := c.t.ObjectIf(key)
+	if exists == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "map", value, exists)
+		return detached(joinPath(c.path, key))
+	}
+	return c.child(joinPath(c.path, key), o)
+}
+
+// Returns the object at the key as a Collector and true, or
+// nil and false if it doesn't exist. An invalid object is recorded
+func (c *Collector) ObjectIf(key string) (*Collector, bool) {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	o, ok := toObject(value)
+	if ok == false {
+		c.record(key, "map", value, exists)
+		return nil, false
+	}
+	return c.child(joinPath(c.path, key), c.t.wrap(o)), true
+}
+
+// Returns the objects at the key as Collectors (see Object).
+// A missing or invalid array is recorded and nil is returned
+func (c *Collector) Objects(key string) []*Collector {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		c.record(key, "objects", value, exists)
+		return nil
+	}
+	return c.objects(key, value)
+}
+
+// Returns the objects at the key as Collectors and true, or
+// nil and false if the key doesn't exist. An invalid array is
+// recorded
+func (c *Collector) ObjectsIf(key string) ([]*Collector, bool) {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return nil, false
+	}
+	n := c.objects(key, value)
+	return n, n != nil
+}
+
+func (c *Collector) objects(key string, value interface{}) []*Collector {
+	a, ok := toArray(value)
+	if ok == false {
+		c.record(key, "objects", value, true)
+		return nil
+	}
+	n := make([]*Collector, len(a))
+	for i, v := range a {
+		path := joinPath(c.path, key) + "[" + strconv.Itoa(i) + "]"
+		o, ok := toObject(v)
+		if ok == false {
+			*c.errors = append(*c.errors, newError(path, "map", v, true))
+			n[i] = detached(path)
+			continue
+		}
+		n[i] = c.child(path, c.t.wrap(o))
+	}
+	return n
+}
+
+// Returns the required bool at the key. A missing or
+// invalid value is recorded and false is returned
+func (c *Collector) Bool(key string) bool {
+	v, ok := c.t.BoolIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "boolean", value, exists)
+		return false
+	}
+	return v
+}
+
+// Returns the optional bool at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) BoolOr(key string, d bool) bool {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.BoolIf(key)
+	if ok == false {
+		c.record(key, "boolean", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required int at the key. A missing or
+// invalid value is recorded and 0 is returned
+func (c *Collector) Int(key string) int {
+	v, ok := c.t.IntIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "int", value, exists)
+		return 0
+	}
+	return v
+}
+
+// Returns the optional int at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) IntOr(key string, d int) int {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.IntIf(key)
+	if ok == false {
+		c.record(key, "int", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required int64 at the key. A missing or
+// invalid value is recorded and 0 is returned
+func (c *Collector) Int64(key string) int64 {
+	v, ok := c.t.Int64If(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "int64", value, exists)
+		return 0
+	}
+	return v
+}
+
+// Returns the optional int64 at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) Int64Or(key string, d int64) int64 {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.Int64If(key)
+	if ok == false {
+		c.record(key, "int64", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required uint at the key. A missing or
+// invalid value is recorded and 0 is returned
+func (c *Collector) Uint(key string) uint {
+	v, ok := c.t.UintIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "uint", value, exists)
+		return 0
+	}
+	return v
+}
+
+// Returns the optional uint at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) UintOr(key string, d uint) uint {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.UintIf(key)
+	if ok == false {
+		c.record(key, "uint", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required float64 at the key. A missing or
+// invalid value is recorded and 0 is returned
+func (c *Collector) Float(key string) float64 {
+	v, ok := c.t.FloatIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "float", value, exists)
+		return 0
+	}
+	return v
+}
+
+// Returns the optional float64 at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) FloatOr(key string, d float64) float64 {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.FloatIf(key)
+	if ok == false {
+		c.record(key, "float", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required string at the key. A missing or
+// invalid value is recorded and "" is returned
+func (c *Collector) String(key string) string {
+	v, ok := c.t.StringIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "string", value, exists)
+		return ""
+	}
+	return v
+}
+
+// Returns the optional string at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) StringOr(key string, d string) string {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.StringIf(key)
+	if ok == false {
+		c.record(key, "string", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required time.Time at the key. A missing or
+// invalid value is recorded and time.Time{} is returned
+func (c *Collector) Time(key string) time.Time {
+	v, ok := c.t.TimeIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "time.Time", value, exists)
+		return time.Time{}
+	}
+	return v
+}
+
+// Returns the optional time.Time at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) TimeOr(key string, d time.Time) time.Time {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.TimeIf(key)
+	if ok == false {
+		c.record(key, "time.Time", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required time.Duration at the key. A missing or
+// invalid value is recorded and 0 is returned
+func (c *Collector) Duration(key string) time.Duration {
+	v, ok := c.t.DurationIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "time.Duration", value, exists)
+		return 0
+	}
+	return v
+}
+
+// Returns the optional time.Duration at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) DurationOr(key string, d time.Duration) time.Duration {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.DurationIf(key)
+	if ok == false {
+		c.record(key, "time.Duration", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required string at the key. A missing or
+// invalid value is recorded and "" is returned
+func (c *Collector) Decimal(key string) string {
+	v, ok := c.t.DecimalIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "decimal", value, exists)
+		return ""
+	}
+	return v
+}
+
+// Returns the optional string at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) DecimalOr(key string, d string) string {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.DecimalIf(key)
+	if ok == false {
+		c.record(key, "decimal", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required []bool at the key. A missing or
+// invalid value is recorded and nil is returned
+func (c *Collector) Bools(key string) []bool {
+	v, ok := c.t.BoolsIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "bools", value, exists)
+		return nil
+	}
+	return v
+}
+
+// Returns the optional []bool at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) BoolsOr(key string, d []bool) []bool {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.BoolsIf(key)
+	if ok == false {
+		c.record(key, "bools", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required []int at the key. A missing or
+// invalid value is recorded and nil is returned
+func (c *Collector) Ints(key string) []int {
+	v, ok := c.t.IntsIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "ints", value, exists)
+		return nil
+	}
+	return v
+}
+
+// Returns the optional []int at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) IntsOr(key string, d []int) []int {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.IntsIf(key)
+	if ok == false {
+		c.record(key, "ints", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required []float64 at the key. A missing or
+// invalid value is recorded and nil is returned
+func (c *Collector) Floats(key string) []float64 {
+	v, ok := c.t.FloatsIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "floats", value, exists)
+		return nil
+	}
+	return v
+}
+
+// Returns the optional []float64 at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) FloatsOr(key string, d []float64) []float64 {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.FloatsIf(key)
+	if ok == false {
+		c.record(key, "floats", value, exists)
+		return d
+	}
+	return v
+}
+
+// Returns the required []string at the key. A missing or
+// invalid value is recorded and nil is returned
+func (c *Collector) Strings(key string) []string {
+	v, ok := c.t.StringsIf(key)
+	if ok == false {
+		value, exists := c.t.Typed[key]
+		c.record(key, "strings", value, exists)
+		return nil
+	}
+	return v
+}
+
+// Returns the optional []string at the key, or the specified
+// value if it doesn't exist. An invalid value is recorded
+func (c *Collector) StringsOr(key string, d []string) []string {
+	value, exists := c.t.Typed[key]
+	if exists == false {
+		return d
+	}
+	v, ok := c.t.StringsIf(key)
+	if ok == false {
+		c.record(key, "strings", value, exists)
+		return d
+	}
+	return v
+}
@@ -0,0 +1,64 @@
+package typed
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Collector(t *testing.T) {
+	typed, _ := JsonString(`{"name": "leto", "age": 3000, "timeout": "5s", "tags": ["a"], "server": {"host": "localhost", "port": 80}, "servers": [{"port": 81}, {"port": 82}]}`)
+	c := typed.Collector()
+	equal(t, c.String("name"), "leto")
+	equal(t, c.IntOr("age", 0), 3000)
+	equal(t, c.Duration("timeout"), 5*time.Second)
+	equal(t, c.BoolOr("debug", true), true)
+	equalList(t, c.Strings("tags"), []string{"a"})
+	server := c.Object("server")
+	equal(t, server.String("host"), "localhost")
+	equal(t, server.Uint("port"), uint(80))
+	servers := c.Objects("servers")
+	equal(t, servers[1].Int("port"), 82)
+	_, exists := c.ObjectIf("other")
+	equal(t, exists, false)
+	equal(t, c.Err(), nil)
+	equal(t, len(c.Errors()), 0)
+}
+
+func Test_CollectorErrors(t *testing.T) {
+	typed, _ := JsonString(`{"age": "old", "debug": 1, "server": {"port": "x"}, "servers": [{"port": true}, 2], "tls": 1}`)
+	c := typed.Collector()
+	equal(t, c.String("name"), "")
+	equal(t, c.IntOr("age", 18), 18)
+	equal(t, c.BoolOr("debug", false), false)
+	equal(t, c.Object("server").IntOr("port", 80), 80)
+	for _, server := range c.Objects("servers") {
+		server.Int("port")
+	}
+	equal(t, c.Object("missing").Int("port"), 0)
+	_, exists := c.ObjectIf("tls")
+	equal(t, exists, false)
+	_, exists = c.ObjectsIf("other")
+	equal(t, exists, false)
+
+	errors := c.Errors()
+	equal(t, len(errors), 8)
+	assertError(t, errors[0], "name", "string", "missing")
+	assertError(t, errors[1], "age", "int", "string")
+	assertError(t, errors[2], "debug", "boolean", "number")
+	assertError(t, errors[3], "server.port", "int", "string")
+	assertError(t, errors[4], "servers[1]", "map", "number")
+	assertError(t, errors[5], "servers[0].port", "int", "boolean")
+	assertError(t, errors[6], "missing", "map", "missing")
+	assertError(t, errors[7], "tls", "map", "number")
+
+	err := c.Err()
+	equal(t, err.Error(), "8 errors: name is required; expected int value for age, got string; expected boolean value for debug, got number; "+
+		"expected int value for server.port, got string; expected map for servers[1], got number; expected int value for servers[0].port, got boolean; "+
+		"missing is required; expected map for tls, got number")
+}
+
+func Test_CollectorSingleError(t *testing.T) {
+	c := New(build("port", "x")).Collector()
+	c.Int("port")
+	equal(t, c.Err().Error(), "expected int value for port, got string")
+}
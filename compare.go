@@ -0,0 +1,282 @@
+package typed
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Returns true if the two values are deeply equal. Numbers are
+// compared by their numeric value, so json.Number("1"), 1.0 and
+// int64(1) are all equal. Typed and map[string]interface{} are
+// interchangeable, as are the various slice representations.
+func equalValues(a interface{}, b interface{}) bool {
+	if ra, ok := toRat(a); ok {
+		rb, ok := toRat(b)
+		return ok && ra.Cmp(rb) == 0
+	}
+
+	if ma, ok := toObject(a); ok {
+		mb, ok := toObject(b)
+		if ok == false || len(ma) != len(mb) {
+			return false
+		}
+		for k, va := range ma {
+			vb, exists := mb[k]
+			if exists == false || equalValues(va, vb) == false {
+				return false
+			}
+		}
+		return true
+	}
+
+	if aa, ok := toArray(a); ok {
+		ab, ok := toArray(b)
+		if ok == false || len(aa) != len(ab) {
+			return false
+		}
+		for i, va := range aa {
+			if equalValues(va, ab[i]) == false {
+				return false
+			}
+		}
+		return true
+	}
+
+	if _, ok := toArray(b); ok {
+		return false
+	}
+	if _, ok := toObject(b); ok {
+		return false
+	}
+	if _, ok := toRat(b); ok {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// Returns the exact numeric value of any Go number or json.Number
+func toRat(value interface{}) (*big.Rat, bool) {
+	switch n := value.(type) {
+	case json.Number:
+		if exponentInRange(string(n)) == false {
+			return nil, false
+		}
+		return new(big.Rat).SetString(string(n))
+	case float64:
+		if r := new(big.Rat); r.SetFloat64(n) != nil {
+			return r, true
+		}
+		return nil, false
+	case float32:
+		if r := new(big.Rat); r.SetFloat64(float64(n)) != nil {
+			return r, true
+		}
+		return nil, false
+	case int:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int8:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int16:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int32:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Rat).SetInt64(n), true
+	case uint:
+		return new(big.Rat).SetUint64(uint64(n)), true
+	case uint8:
+		return new(big.Rat).SetUint64(uint64(n)), true
+	case uint16:
+		return new(big.Rat).SetUint64(uint64(n)), true
+	case uint32:
+		return new(big.Rat).SetUint64(uint64(n)), true
+	case uint64:
+		return new(big.Rat).SetUint64(n), true
+	}
+	return nil, false
+}
+
+// The largest exponent accepted in a json.Number: big.Rat would
+// expand 1e1000000000 into a billion digits
+const maxExponent = 1000
+
+func exponentInRange(s string) bool {
+	e := strings.IndexAny(s, "eE")
+	if e == -1 {
+		return true
+	}
+	exp, err := strconv.Atoi(s[e+1:])
+	return err == nil && exp <= maxExponent && exp >= -maxExponent
+}
+
+// The precision of the big.Float a number whose exponent is out of
+// range is compared with
+const hugePrecision = 512
+
+// Splits a json.Number whose exponent is out of range (which toRat
+// rejects) into digits without trailing zeros and a power of ten:
+// its value is digits × 10^exponent
+func parseHuge(value interface{}) (*big.Int, int64, bool) {
+	n, ok := value.(json.Number)
+	if ok == false || exponentInRange(string(n)) {
+		return nil, 0, false
+	}
+	s := string(n)
+	e := strings.IndexAny(s, "eE")
+	if e == -1 {
+		return nil, 0, false
+	}
+	exponent, err := strconv.ParseInt(s[e+1:], 10, 64)
+	if err != nil && err.(*strconv.NumError).Err != strconv.ErrRange {
+		return nil, 0, false
+	}
+	// far beyond what a big.Float holds, and what the digits can offset
+	if exponent > 1<<40 {
+		exponent = 1 << 40
+	} else if exponent < -1<<40 {
+		exponent = -1 << 40
+	}
+
+	mantissa, sign := s[:e], ""
+	if len(mantissa) > 0 && mantissa[0] == '-' {
+		sign, mantissa = "-", mantissa[1:]
+	}
+	whole, fraction := mantissa, ""
+	if dot := strings.IndexByte(mantissa, '.'); dot != -1 {
+		whole, fraction = mantissa[:dot], mantissa[dot+1:]
+	}
+	if len(whole) == 0 || isDigits(whole) == false || isDigits(fraction) == false {
+		return nil, 0, false
+	}
+	digits := strings.TrimRight(whole+fraction, "0")
+	if digits == "" {
+		return new(big.Int), 0, true
+	}
+	// the value is (whole+fraction) × 10^(exponent-len(fraction)),
+	// and each trailing zero removed adds one to the exponent
+	exponent += int64(len(whole) - len(digits))
+	d, _ := new(big.Int).SetString(sign+digits, 10)
+	return d, exponent, true
+}
+
+// Returns digits × 10^exponent as a big.Float. It's infinite, or
+// zero, when the exponent is beyond what a big.Float can hold
+func hugeFloat(digits *big.Int, exponent int64) *big.Float {
+	f, ok := new(big.Float).SetPrec(hugePrecision).SetString(digits.String() + "e" + strconv.FormatInt(exponent, 10))
+	if ok {
+		return f
+	}
+	if exponent > 0 {
+		return new(big.Float).SetInf(digits.Sign() < 0)
+	}
+	return new(big.Float)
+}
+
+// Compares a number (see toNumber) with r, including json.Numbers
+// whose exponent is out of range
+func compareNumber(value interface{}, r *big.Rat) (int, bool) {
+	if n, ok := toNumber(value); ok {
+		return n.Cmp(r), true
+	}
+	digits, exponent, ok := parseHuge(value)
+	if ok == false {
+		return 0, false
+	}
+	f := hugeFloat(digits, exponent)
+	c := f.Cmp(new(big.Float).SetPrec(hugePrecision).SetRat(r))
+	if c == 0 && f.Sign() == 0 {
+		// too small for a big.Float, but not 0
+		return digits.Sign(), true
+	}
+	return c, true
+}
+
+// Returns true if the value is a number (see toNumber) without a
+// fractional part, including json.Numbers whose exponent is out of range
+func isInteger(value interface{}) bool {
+	if n, ok := toNumber(value); ok {
+		return n.IsInt()
+	}
+	// the digits have no trailing zeros
+	_, exponent, ok := parseHuge(value)
+	return ok && exponent >= 0
+}
+
+// Returns true if the number (see toNumber) is a multiple of m, which
+// must be positive, including json.Numbers whose exponent is out of range
+func isMultiple(value interface{}, m *big.Rat) bool {
+	if n, ok := toNumber(value); ok {
+		return new(big.Rat).Quo(n, m).IsInt()
+	}
+	digits, exponent, ok := parseHuge(value)
+	if ok == false {
+		return false
+	}
+	// digits × 10^exponent × denom / num must be an integer
+	dividend := new(big.Int).Mul(new(big.Int).Abs(digits), m.Denom())
+	if dividend.Sign() == 0 {
+		return true
+	}
+	if exponent < 0 {
+		// 10^-exponent > 2^-exponent > dividend
+		if -exponent > int64(dividend.BitLen()) {
+			return false
+		}
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(-exponent), nil)
+		divisor.Mul(divisor, m.Num())
+		return new(big.Int).Rem(dividend, divisor).Sign() == 0
+	}
+
+	// what's left of num once divided by the dividend must divide
+	// 10^exponent, so only be made of at most exponent 2s and 5s
+	num := m.Num()
+	rest := new(big.Int).Quo(num, new(big.Int).GCD(nil, nil, num, dividend))
+	twos := int64(rest.TrailingZeroBits())
+	rest.Rsh(rest, uint(twos))
+	five, mod := big.NewInt(5), new(big.Int)
+	fives := int64(0)
+	for rest.Cmp(five) >= 0 {
+		if new(big.Int).QuoRem(rest, five, mod); mod.Sign() != 0 {
+			break
+		}
+		rest.Quo(rest, five)
+		fives++
+	}
+	return rest.Cmp(big.NewInt(1)) == 0 && twos <= exponent && fives <= exponent
+}
+
+// Returns the value as a []interface{} if it's an array. The
+// elements of typed slices (e.g. []int) are boxed
+func toArray(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case []Typed:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = e
+		}
+		return a, true
+	case []map[string]interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = e
+		}
+		return a, true
+	case []byte, json.RawMessage:
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	a := make([]interface{}, rv.Len())
+	for i := range a {
+		a[i] = rv.Index(i).Interface()
+	}
+	return a, true
+}
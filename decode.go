@@ -0,0 +1,341 @@
+package typed
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	typedType  = reflect.TypeOf(Typed(nil))
+	timeType   = reflect.TypeOf(time.Time{})
+	numberType = reflect.TypeOf(json.Number(""))
+	textType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Returned by Decode when a value can't be converted into
+// the type of its destination
+type DecodeError struct {
+	// The dotted path of the value (e.g. "servers[1].port")
+	Path  string
+	Value interface{}
+	Type  reflect.Type
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("cannot decode %s into %s at %s", jsonType(e.Value), e.Type, e.Path)
+}
+
+// Decodes the value at the key into out, which must be a non-nil
+// pointer. Structs are populated using their json tags (as
+// encoding/json would) and values are converted using the same
+// rules as the accessors (e.g. "42" can be decoded into an int,
+// 2.5 into an int like Int but not into an int64 like Int64).
+// KeyNotFound is returned if the key doesn't exist. An empty key
+// decodes the whole document (like ToBytes).
+func (t Typed) Decode(key string, out interface{}) error {
+	if len(key) == 0 {
+		return t.DecodeAll(out)
+	}
+	value, exists := t[key]
+	if exists == false {
+		return KeyNotFound
+	}
+	return standard.decodeInto(key, value, out)
+}
+
+// Decodes the whole document into out (see Decode)
+func (t Typed) DecodeAll(out interface{}) error {
+	return standard.decodeInto("", t, out)
+}
+
+func (p Policy) decodeInto(path string, value interface{}, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("typed: decode requires a non-nil pointer")
+	}
+	return p.decodeValue(path, value, rv.Elem())
+}
+
+func (p Policy) decodeValue(path string, value interface{}, rv reflect.Value) error {
+	if value == nil {
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+		return nil
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return p.decodeValue(path, value, rv.Elem())
+	}
+
+	switch rv.Type() {
+	case typedType:
+		if o, ok := toObject(value); ok {
+			rv.Set(reflect.ValueOf(o))
+			return nil
+		}
+		return &DecodeError{Path: path, Value: value, Type: rv.Type()}
+	case timeType:
+		if tt, ok := p.toTime(value); ok {
+			rv.Set(reflect.ValueOf(tt))
+			return nil
+		}
+	}
+
+	if rv.CanAddr() {
+		if u, ok := rv.Addr().Interface().(json.Unmarshaler); ok {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			return u.UnmarshalJSON(data)
+		}
+		if s, ok := value.(string); ok {
+			if u, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+				return u.UnmarshalText([]byte(s))
+			}
+		}
+	}
+
+	ok := false
+	switch rv.Kind() {
+	case reflect.Bool:
+		var b bool
+		if b, ok = p.toBool(value); ok {
+			rv.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if rv.Kind() == reflect.Int {
+			// like Int, which truncates floats (see Policy.TruncateFloats),
+			// while the fixed-width kinds are exact like Int8 ... Int64
+			var n int
+			n, ok = p.toInt(value)
+			i = int64(n)
+		} else {
+			i, ok = p.toInt64(value)
+		}
+		if ok && rv.OverflowInt(i) == false {
+			rv.SetInt(i)
+		} else {
+			ok = false
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var i uint64
+		if i, ok = p.toUint64(value); ok && rv.OverflowUint(i) == false {
+			rv.SetUint(i)
+		} else {
+			ok = false
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, ok = p.toFloat(value); ok && rv.OverflowFloat(f) == false {
+			rv.SetFloat(f)
+		} else {
+			ok = false
+		}
+	case reflect.String:
+		var s string
+		if s, ok = p.toString(value); ok {
+			rv.SetString(s)
+		}
+	case reflect.Interface:
+		if rv.NumMethod() == 0 {
+			rv.Set(reflect.ValueOf(value))
+			return nil
+		}
+	case reflect.Struct:
+		if o, isObject := toObject(value); isObject {
+			return p.decodeStruct(path, o, rv)
+		}
+	case reflect.Map:
+		if o, isObject := toObject(value); isObject {
+			return p.decodeMap(path, o, rv)
+		}
+	case reflect.Slice:
+		if s, isString := value.(string); isString && rv.Type().Elem().Kind() == reflect.Uint8 {
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return &DecodeError{Path: path, Value: value, Type: rv.Type()}
+			}
+			rv.SetBytes(data)
+			return nil
+		}
+		if a, isArray := toArray(value); isArray {
+			slice := reflect.MakeSlice(rv.Type(), len(a), len(a))
+			if err := p.decodeElements(path, a, slice); err != nil {
+				return err
+			}
+			rv.Set(slice)
+			return nil
+		}
+	case reflect.Array:
+		if a, isArray := toArray(value); isArray {
+			if len(a) > rv.Len() {
+				a = a[:rv.Len()]
+			}
+			return p.decodeElements(path, a, rv)
+		}
+	}
+
+	if ok == false {
+		return &DecodeError{Path: path, Value: value, Type: rv.Type()}
+	}
+	return nil
+}
+
+func (p Policy) decodeElements(path string, a []interface{}, rv reflect.Value) error {
+	for i, value := range a {
+		if err := p.decodeValue(fmt.Sprintf("%s[%d]", path, i), value, rv.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p Policy) decodeMap(path string, o Typed, rv reflect.Value) error {
+	mt := rv.Type()
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(mt, len(o)))
+	}
+	for k, value := range o {
+		key := reflect.New(mt.Key()).Elem()
+		switch {
+		case reflect.PtrTo(mt.Key()).Implements(textType):
+			if err := key.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(k)); err != nil {
+				return err
+			}
+		case mt.Key().Kind() == reflect.String:
+			key.SetString(k)
+		default:
+			// JSON keys are always strings: "1" is the key of a map[int]T
+			keys := p
+			keys.StringToNumber = true
+			if err := keys.decodeValue(joinPath(path, k), k, key); err != nil {
+				return err
+			}
+		}
+		elem := reflect.New(mt.Elem()).Elem()
+		if err := p.decodeValue(joinPath(path, k), value, elem); err != nil {
+			return err
+		}
+		rv.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+func (p Policy) decodeStruct(path string, o Typed, rv reflect.Value) error {
+	fields := structFields(rv.Type())
+	for key, value := range o {
+		f, ok := fields[key]
+		if ok == false {
+			for name, candidate := range fields {
+				if strings.EqualFold(name, key) {
+					f, ok = candidate, true
+					break
+				}
+			}
+			if ok == false {
+				continue
+			}
+		}
+		if err := p.decodeValue(joinPath(path, key), value, fieldByIndex(rv, f.index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the nested field, allocating any nil embedded pointer
+func fieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv
+}
+
+type structField struct {
+	index     []int
+	omitEmpty bool
+}
+
+// Returns the fields of the struct keyed by their JSON name.
+// Follows the encoding/json rules: the json tag names the field,
+// "-" skips it and the fields of untagged embedded structs are
+// promoted (a field at a shallower depth wins). Embedded structs
+// are walked a depth at a time, and each type only once, so that
+// a struct embedding itself through a pointer terminates.
+func structFields(st reflect.Type) map[string]structField {
+	type embedded struct {
+		t     reflect.Type
+		index []int
+	}
+	fields := make(map[string]structField)
+	depths := make(map[string]int)
+	visited := make(map[reflect.Type]bool)
+	for current := []embedded{{t: st}}; len(current) > 0; {
+		var next []embedded
+		for _, e := range current {
+			if visited[e.t] {
+				continue
+			}
+			visited[e.t] = true
+			for i := 0; i < e.t.NumField(); i++ {
+				sf := e.t.Field(i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, options := tag, ""
+				if comma := strings.IndexByte(tag, ','); comma != -1 {
+					name, options = tag[:comma], tag[comma:]
+				}
+
+				fieldIndex := append(append(make([]int, 0, len(e.index)+1), e.index...), i)
+				if sf.Anonymous && name == "" {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						if sf.PkgPath != "" {
+							// can't allocate a pointer to an unexported struct
+							continue
+						}
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, embedded{t: ft, index: fieldIndex})
+						continue
+					}
+				}
+				if sf.PkgPath != "" {
+					continue
+				}
+				if name == "" {
+					name = sf.Name
+				}
+				if depth, exists := depths[name]; exists && depth <= len(e.index) {
+					continue
+				}
+				depths[name] = len(e.index)
+				fields[name] = structField{index: fieldIndex, omitEmpty: strings.Contains(options+",", ",omitempty,")}
+			}
+		}
+		current = next
+	}
+	return fields
+}
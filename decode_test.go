@@ -0,0 +1,158 @@
+package typed
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+type decodeBase struct {
+	ID      int `json:"id"`
+	Created time.Time
+}
+
+type decodeServer struct {
+	Host string `json:"host"`
+	Port uint16 `json:"port"`
+}
+
+type decodeConfig struct {
+	decodeBase
+	*DecodeExtra
+	Name     string         `json:"name"`
+	Ratio    float32        `json:"ratio"`
+	Enabled  bool           `json:"enabled"`
+	Tags     []string       `json:"tags"`
+	Servers  []decodeServer `json:"servers"`
+	Primary  *decodeServer  `json:"primary"`
+	Limits   map[string]int `json:"limits"`
+	Codes    map[int]string `json:"codes"`
+	Raw      interface{}    `json:"raw"`
+	Meta     Typed          `json:"meta"`
+	Pair     [2]int         `json:"pair"`
+	Data     []byte         `json:"data"`
+	IP       net.IP         `json:"ip"`
+	Ignored  string         `json:"-"`
+	Nullable *string        `json:"nullable"`
+	Untagged string
+	hidden   string
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+type DecodeExtra struct {
+	Extra string `json:"extra"`
+}
+
+// embed each other, or themselves, through exported pointers
+type DecodeNode struct {
+	*DecodeNode
+	Name string `json:"name"`
+}
+
+type DecodeLeft struct {
+	*DecodeRight
+	Left int `json:"left"`
+}
+
+type DecodeRight struct {
+	*DecodeLeft
+	Right int `json:"right"`
+}
+
+func Test_DecodeAll(t *testing.T) {
+	typed, _ := JsonString(`{
+		"id": "42", "Created": "2020-01-02T03:04:05Z", "extra": "x",
+		"name": "leto", "ratio": 0.5, "enabled": true, "tags": ["a", "b"],
+		"servers": [{"host": "a", "port": 80}, {"host": "b", "port": "81"}],
+		"primary": {"host": "p", "port": 9000},
+		"limits": {"a": 1, "b": "2"}, "codes": {"200": "ok"},
+		"raw": {"x": 1}, "meta": {"y": 2}, "pair": [1, 2, 3],
+		"data": "aGk=", "ip": "127.0.0.1", "Ignored": "no", "nullable": null,
+		"untagged": "yes", "hidden": "no", "unknown": 1
+	}`)
+
+	var config decodeConfig
+	equal(t, typed.DecodeAll(&config), nil)
+	equal(t, config.ID, 42)
+	equal(t, config.Created, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	equal(t, config.Extra, "x")
+	equal(t, config.Name, "leto")
+	equal(t, config.Ratio, float32(0.5))
+	equal(t, config.Enabled, true)
+	equalList(t, config.Tags, []string{"a", "b"})
+	equal(t, config.Servers[1].Host, "b")
+	equal(t, config.Servers[1].Port, uint16(81))
+	equal(t, config.Primary.Port, uint16(9000))
+	equal(t, config.Limits["b"], 2)
+	equal(t, config.Codes[200], "ok")
+	equal(t, config.Raw.(map[string]interface{})["x"], typed.ObjectPath("raw").Interface("x"))
+	equal(t, config.Meta.Int("y"), 2)
+	equal(t, config.Pair, [2]int{1, 2})
+	equal(t, string(config.Data), "hi")
+	equal(t, config.IP.String(), "127.0.0.1")
+	equal(t, config.Ignored, "")
+	equal(t, config.Nullable == nil, true)
+	equal(t, config.Untagged, "yes")
+	equal(t, config.hidden, "")
+}
+
+func Test_Decode(t *testing.T) {
+	typed, _ := JsonString(`{"server": {"host": "localhost", "port": 80}, "ports": [1, 2]}`)
+	var server decodeServer
+	equal(t, typed.Decode("server", &server), nil)
+	equal(t, server.Host, "localhost")
+	equal(t, server.Port, uint16(80))
+
+	var ports []int
+	equal(t, typed.Decode("ports", &ports), nil)
+	equalList(t, ports, []int{1, 2})
+
+	var all map[string]interface{}
+	equal(t, typed.Decode("", &all), nil)
+	equal(t, len(all), 2)
+
+	equal(t, typed.Decode("other", &server), KeyNotFound)
+	equal(t, typed.Decode("server", server).Error(), "typed: decode requires a non-nil pointer")
+}
+
+func Test_DecodeRecursiveEmbedding(t *testing.T) {
+	typed := New(build("name", "leto", "left", 1, "right", 2))
+	var node DecodeNode
+	equal(t, typed.DecodeAll(&node), nil)
+	equal(t, node.Name, "leto")
+	equal(t, node.DecodeNode == nil, true)
+
+	var left DecodeLeft
+	equal(t, typed.DecodeAll(&left), nil)
+	equal(t, left.Left, 1)
+	equal(t, left.Right, 2)
+}
+
+func Test_DecodeErrors(t *testing.T) {
+	var server decodeServer
+	var config decodeConfig
+	cases := []struct {
+		json     string
+		out      interface{}
+		expected string
+	}{
+		{`{"port": 70000}`, &server, "cannot decode number into uint16 at port"},
+		{`{"port": -1}`, &server, "cannot decode number into uint16 at port"},
+		{`{"host": 1}`, &server, "cannot decode number into string at host"},
+		{`{"servers": [{"port": 1}, {"port": true}]}`, &config, "cannot decode boolean into uint16 at servers[1].port"},
+		{`{"limits": {"a": "b"}}`, &config, "cannot decode string into int at limits.a"},
+		{`{"tags": "a"}`, &config, "cannot decode string into []string at tags"},
+		{`{"meta": 1}`, &config, "cannot decode number into typed.Typed at meta"},
+		{`{"data": "!"}`, &config, "cannot decode string into []uint8 at data"},
+		{`{"port": 80.5}`, &server, "cannot decode number into uint16 at port"},
+		{`{"id": 1e20}`, &config, "cannot decode number into int at id"},
+		{`{"ratio": 1e300}`, &config, "cannot decode number into float32 at ratio"},
+	}
+	for _, c := range cases {
+		typed, _ := JsonString(c.json)
+		err := typed.DecodeAll(c.out)
+		equal(t, err.Error(), c.expected)
+		_, ok := err.(*DecodeError)
+		equal(t, ok, true)
+	}
+}
@@ -0,0 +1,114 @@
+package typed
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+type ChangeType int
+
+const (
+	Added ChangeType = iota
+	Removed
+	Changed
+)
+
+func (c ChangeType) String() string {
+	switch c {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// A single difference between two documents
+type Change struct {
+	Type ChangeType
+	// A JSON Pointer to the value
+	Path string
+	// The value in the first document (nil when added)
+	Old interface{}
+	// The value in the second document (nil when removed)
+	New interface{}
+}
+
+func (c Change) String() string {
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("+ %s: %v", c.Path, c.New)
+	case Removed:
+		return fmt.Sprintf("- %s: %v", c.Path, c.Old)
+	}
+	return fmt.Sprintf("~ %s: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// Returns the changes which transform a into b, ordered by path.
+// Objects are compared key by key and arrays index by index. Numbers
+// are compared by value, so json.Number("1"), 1.0 and 1 are equal.
+// When a value changes type (e.g. from an object to a string), a
+// single Changed entry is reported for it.
+func Diff(a Typed, b Typed) []Change {
+	return diffObjects(nil, "", a, b)
+}
+
+func diffObjects(changes []Change, path string, a map[string]interface{}, b map[string]interface{}) []Change {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, exists := a[key]; exists == false {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		p := path + "/" + escapePointer(key)
+		old, inA := a[key]
+		current, inB := b[key]
+		if inA == false {
+			changes = append(changes, Change{Type: Added, Path: p, New: current})
+		} else if inB == false {
+			changes = append(changes, Change{Type: Removed, Path: p, Old: old})
+		} else {
+			changes = diffValues(changes, p, old, current)
+		}
+	}
+	return changes
+}
+
+func diffValues(changes []Change, path string, old interface{}, current interface{}) []Change {
+	if oo, ok := toObject(old); ok {
+		if no, ok := toObject(current); ok {
+			return diffObjects(changes, path, oo, no)
+		}
+	} else if oa, ok := toArray(old); ok {
+		if na, ok := toArray(current); ok {
+			return diffArrays(changes, path, oa, na)
+		}
+	}
+	if equalValues(old, current) {
+		return changes
+	}
+	return append(changes, Change{Type: Changed, Path: path, Old: old, New: current})
+}
+
+func diffArrays(changes []Change, path string, old []interface{}, current []interface{}) []Change {
+	for i := 0; i < len(old) || i < len(current); i++ {
+		p := path + "/" + strconv.Itoa(i)
+		if i >= len(current) {
+			changes = append(changes, Change{Type: Removed, Path: p, Old: old[i]})
+		} else if i >= len(old) {
+			changes = append(changes, Change{Type: Added, Path: p, New: current[i]})
+		} else {
+			changes = diffValues(changes, p, old[i], current[i])
+		}
+	}
+	return changes
+}
@@ -0,0 +1,60 @@
+package typed
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_Diff(t *testing.T) {
+	a, _ := JsonString(`{"server": {"host": "localhost", "port": 80}, "tags": ["a", "b", "c"], "log": true, "a/b": 1, "same": {"x": [1, {"y": 2}]}}`)
+	b, _ := JsonString(`{"server": {"host": "localhost", "port": 8080, "tls": true}, "tags": ["a", "z"], "name": "leto", "a/b": 2, "same": {"x": [1.0, {"y": 2}]}}`)
+	changes := Diff(a, b)
+	equal(t, len(changes), 7)
+	equal(t, changes[0].String(), "~ /a~1b: 1 -> 2")
+	equal(t, changes[1].String(), "- /log: true")
+	equal(t, changes[2].String(), "+ /name: leto")
+	equal(t, changes[3].String(), "~ /server/port: 80 -> 8080")
+	equal(t, changes[4].String(), "+ /server/tls: true")
+	equal(t, changes[5].String(), "~ /tags/1: b -> z")
+	equal(t, changes[6].String(), "- /tags/2: c")
+
+	equal(t, changes[3].Type, Changed)
+	equal(t, changes[3].Path, "/server/port")
+	equal(t, changes[3].Old, json.Number("80"))
+	equal(t, changes[3].New, json.Number("8080"))
+	equal(t, changes[1].New, nil)
+	equal(t, changes[2].Old, nil)
+}
+
+func Test_DiffNumbers(t *testing.T) {
+	a := New(build("i", 1, "f", 2.0, "n", json.Number("3"), "i64", int64(4)))
+	b := New(build("i", json.Number("1.0"), "f", 2, "n", 3.0, "i64", 4))
+	equal(t, len(Diff(a, b)), 0)
+
+	b = New(build("i", "1", "f", 2.5, "n", 3.0, "i64", 4))
+	changes := Diff(a, b)
+	equal(t, len(changes), 2)
+	equal(t, changes[0].Path, "/f")
+	equal(t, changes[1].Path, "/i")
+}
+
+func Test_DiffTypeChange(t *testing.T) {
+	a, _ := JsonString(`{"server": {"port": 80}, "tags": ["a"], "x": null}`)
+	b, _ := JsonString(`{"server": "localhost:80", "tags": {"0": "a"}, "x": 1}`)
+	changes := Diff(a, b)
+	equal(t, len(changes), 3)
+	equal(t, changes[0].Path, "/server")
+	equal(t, changes[0].Type, Changed)
+	equal(t, changes[1].Path, "/tags")
+	equal(t, changes[2].String(), "~ /x: <nil> -> 1")
+}
+
+func Test_DiffMixedShapes(t *testing.T) {
+	a := New(build("users", []Typed{build("id", 1)}, "ids", []int{1, 2}))
+	b := New(build("users", []interface{}{map[string]interface{}{"id": 1}}, "ids", []interface{}{1, 2, 3}))
+	changes := Diff(a, b)
+	equal(t, len(changes), 1)
+	equal(t, changes[0].String(), "+ /ids/2: 3")
+	equal(t, len(Diff(nil, nil)), 0)
+	equal(t, Change{Type: ChangeType(9)}.Type.String(), "unknown")
+}
@@ -0,0 +1,202 @@
+package typed
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Returns a duration at the key, or 0 if it doesn't
+// exist or isn't a duration
+func (t Typed) Duration(key string) time.Duration {
+	return t.DurationOr(key, 0)
+}
+
+// Returns a duration at the key, or the specified
+// value if it doesn't exist or isn't a duration
+func (t Typed) DurationOr(key string, d time.Duration) time.Duration {
+	if value, exists := t.DurationIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a time.Duration or panics
+func (t Typed) DurationMust(key string) time.Duration {
+	d, exists := t.DurationIf(key)
+	if exists == false {
+		panic(t.keyError(key, "time.Duration"))
+	}
+	return d
+}
+
+// Returns a time.Duration or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) DurationE(key string) (time.Duration, error) {
+	d, exists := t.DurationIf(key)
+	if exists == false {
+		return d, t.keyError(key, "time.Duration")
+	}
+	return d, nil
+}
+
+// Returns a duration at the key and whether or not the key
+// existed and the value was a duration. Strings are parsed with
+// time.ParseDuration ("1500ms", "1h30m") or as an ISO 8601
+// duration ("PT30S", "P1DT2H"). Numbers, including numeric
+// strings, are in seconds (see Policy.DurationUnit for another unit).
+func (t Typed) DurationIf(key string) (time.Duration, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toDuration(value, time.Second)
+}
+
+// Numbers, including numeric strings, are in unit
+func toDuration(value interface{}, unit time.Duration) (time.Duration, bool) {
+	switch t := value.(type) {
+	case time.Duration:
+		return t, true
+	case string:
+		if d, err := time.ParseDuration(t); err == nil {
+			return d, true
+		}
+		if d, ok := parseISODuration(t); ok {
+			return d, true
+		}
+		if i, err := strconv.ParseInt(t, 10, 64); err == nil {
+			return scaleDuration(i, unit)
+		}
+		if f, err := strconv.ParseFloat(t, 64); err == nil {
+			return scaleDurationFloat(f, unit)
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return scaleDuration(i, unit)
+		}
+		if f, err := t.Float64(); err == nil {
+			return scaleDurationFloat(f, unit)
+		}
+	case int:
+		return scaleDuration(int64(t), unit)
+	case int64:
+		return scaleDuration(t, unit)
+	case float64:
+		return scaleDurationFloat(t, unit)
+	}
+	return 0, false
+}
+
+func scaleDuration(i int64, unit time.Duration) (time.Duration, bool) {
+	if unit != 0 && (i > math.MaxInt64/int64(unit) || i < math.MinInt64/int64(unit)) {
+		return 0, false
+	}
+	return time.Duration(i * int64(unit)), true
+}
+
+func scaleDurationFloat(f float64, unit time.Duration) (time.Duration, bool) {
+	return floatDuration(f * float64(unit))
+}
+
+func floatDuration(f float64) (time.Duration, bool) {
+	f = math.Round(f)
+	if math.IsNaN(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, false
+	}
+	return time.Duration(f), true
+}
+
+// Parses an ISO 8601 duration: P[nW][nD][T[nH][nM][nS]], with an
+// optional leading sign and fractional values. Years and months
+// aren't supported since their length varies
+func parseISODuration(s string) (time.Duration, bool) {
+	sign := 1.0
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	if len(s) < 2 || s[0] != 'P' {
+		return 0, false
+	}
+	s = s[1:]
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	order := "WD"
+	total := 0.0
+	inTime := false
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return 0, false
+			}
+			inTime = true
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+			order = "HMS"
+			s = s[1:]
+			continue
+		}
+		end := strings.IndexAny(s, order)
+		if end < 1 {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(strings.Replace(s[:end], ",", ".", 1), 64)
+		if err != nil || n < 0 || strings.IndexAny(s[:end], "eE+-") != -1 {
+			return 0, false
+		}
+		total += n * float64(units[s[end]])
+		// designators must appear in order, and only once
+		order = order[strings.IndexByte(order, s[end])+1:]
+		s = s[end+1:]
+	}
+	return floatDuration(sign * total)
+}
+
+// Returns an slice of durations, or a nil slice
+func (t Typed) Durations(key string) []time.Duration {
+	return t.DurationsOr(key, nil)
+}
+
+// Returns an slice of durations, or the specified slice
+// if the key doesn't exist or isn't a valid []time.Duration
+func (t Typed) DurationsOr(key string, d []time.Duration) []time.Duration {
+	n, ok := t.DurationsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a duration slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid duration)
+// Values are parsed the same way as DurationIf
+func (t Typed) DurationsIf(key string) ([]time.Duration, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toDurations(value)
+}
+
+func toDurations(value interface{}) ([]time.Duration, bool) {
+	if n, ok := value.([]time.Duration); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]time.Duration, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toDuration(a[i], time.Second); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
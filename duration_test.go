@@ -0,0 +1,96 @@
+package typed
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Duration(t *testing.T) {
+	typed, _ := JsonString(`{"go": "1500ms", "iso": "PT30S", "secs": 30, "frac": 1.5, "str": "45", "native": 0, "bad": "soon", "nope": true}`)
+	typed["native"] = 2 * time.Minute
+	equal(t, typed.Duration("go"), 1500*time.Millisecond)
+	equal(t, typed.Duration("iso"), 30*time.Second)
+	equal(t, typed.Duration("secs"), 30*time.Second)
+	equal(t, typed.Duration("frac"), 1500*time.Millisecond)
+	equal(t, typed.Duration("str"), 45*time.Second)
+	equal(t, typed.Duration("native"), 2*time.Minute)
+	equal(t, typed.Duration("bad"), time.Duration(0))
+	equal(t, typed.Duration("other"), time.Duration(0))
+	equal(t, typed.DurationOr("nope", time.Second), time.Second)
+	equal(t, typed.DurationMust("go"), 1500*time.Millisecond)
+
+	_, exists := typed.DurationIf("bad")
+	equal(t, exists, false)
+	_, exists = typed.DurationIf("other")
+	equal(t, exists, false)
+}
+
+func Test_DurationMust(t *testing.T) {
+	defer mustTest(t, "expected time.Duration value for fail")
+	New(build("fail", "x")).DurationMust("fail")
+	t.FailNow()
+}
+
+func Test_DurationUnit(t *testing.T) {
+	policy := Standard()
+	policy.DurationUnit = time.Millisecond
+	typed, _ := JsonString(`{"timeout": 1500, "go": "2s", "huge": 9223372036854775807, "timeouts": [1, "2"]}`)
+	millis := typed.WithPolicy(policy)
+	equal(t, millis.Duration("timeout"), 1500*time.Millisecond)
+	equal(t, millis.Duration("go"), 2*time.Second)
+	equalList(t, millis.Durations("timeouts"), []time.Duration{time.Millisecond, 2 * time.Millisecond})
+	equal(t, millis.Object("other").Duration("timeout"), time.Duration(0))
+	_, exists := millis.DurationIf("huge")
+	equal(t, exists, false)
+
+	// Typed and the presets are in seconds
+	equal(t, typed.Duration("timeout"), 1500*time.Second)
+	equal(t, typed.WithPolicy(Lenient()).Duration("timeout"), 1500*time.Second)
+}
+
+func Test_ISODuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"PT30S":        30 * time.Second,
+		"PT1.5S":       1500 * time.Millisecond,
+		"PT0,5S":       500 * time.Millisecond,
+		"PT1H30M":      90 * time.Minute,
+		"P1D":          24 * time.Hour,
+		"P1W":          7 * 24 * time.Hour,
+		"P1DT2H3M4S":   26*time.Hour + 3*time.Minute + 4*time.Second,
+		"-PT10M":       -10 * time.Minute,
+		"PT0S":         0,
+		"P0D":          0,
+		"PT36H":        36 * time.Hour,
+		"P2DT0.25H":    48*time.Hour + 15*time.Minute,
+		"PT1M0.001S":   time.Minute + time.Millisecond,
+		"+PT1S":        time.Second,
+		"PT1000000H":   1000000 * time.Hour,
+		"P1W2D":        9 * 24 * time.Hour,
+		"PT2562047H":   2562047 * time.Hour,
+		"PT0.000001S":  time.Microsecond,
+		"PT0.0000001S": 100 * time.Nanosecond,
+	}
+	for input, expected := range valid {
+		d, ok := parseISODuration(input)
+		equal(t, ok, true)
+		equal(t, d, expected)
+	}
+
+	invalid := []string{"", "P", "PT", "P1DT", "1D", "P1Y", "P1M", "PT1D", "P1H", "PT1S1M", "PT1H1H", "PTS", "P-1D", "PT1e3S", "P1DT2HT3M", "PT99999999999H"}
+	for _, input := range invalid {
+		_, ok := parseISODuration(input)
+		equal(t, ok, false)
+	}
+}
+
+func Test_Durations(t *testing.T) {
+	typed, _ := JsonString(`{"timeouts": ["1s", "PT2S", 3], "fail": ["1s", "x"], "nope": 1}`)
+	equalList(t, typed.Durations("timeouts"), []time.Duration{time.Second, 2 * time.Second, 3 * time.Second})
+	equal(t, len(typed.Durations("other")), 0)
+	equal(t, typed.DurationsOr("nope", []time.Duration{time.Minute})[0], time.Minute)
+	equal(t, New(build("native", []time.Duration{time.Hour})).Durations("native")[0], time.Hour)
+
+	values, exists := typed.DurationsIf("fail")
+	equal(t, values[0], time.Second)
+	equal(t, exists, false)
+}
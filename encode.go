@@ -0,0 +1,250 @@
+package typed
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Creates a Typed helper from a struct or a map. Structs are
+// converted using their json tags (including omitempty and embedded
+// structs) and any map key is converted to a string. The result has
+// the same shape as JsonReader's: objects are map[string]interface{},
+// arrays are []interface{} and numbers are json.Number. time.Time
+// values are kept as-is.
+func FromStruct(v interface{}) (Typed, error) {
+	e := &encoder{visiting: make(map[visit]bool)}
+	value, err := e.encodeValue("", reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	m, ok := value.(map[string]interface{})
+	if ok == false {
+		return nil, fmt.Errorf("typed: cannot create a Typed from %T", v)
+	}
+	return Typed(m), nil
+}
+
+type encoder struct {
+	// the pointers, maps and slices being encoded, to detect cycles
+	visiting map[visit]bool
+}
+
+type visit struct {
+	t reflect.Type
+	p uintptr
+	l int
+}
+
+// Marks the pointer, map or slice as being encoded. Returns an
+// error if it already is: it contains itself
+func (e *encoder) enter(path string, rv reflect.Value) (visit, error) {
+	v := visit{t: rv.Type(), p: rv.Pointer()}
+	if rv.Kind() == reflect.Slice {
+		v.l = rv.Len()
+	}
+	if e.visiting[v] {
+		return v, fmt.Errorf("typed: cycle through %s at %s", rv.Type(), path)
+	}
+	e.visiting[v] = true
+	return v, nil
+}
+
+func (e *encoder) encodeValue(path string, rv reflect.Value) (interface{}, error) {
+	if rv.IsValid() == false {
+		return nil, nil
+	}
+	if rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Kind() == reflect.Interface {
+			return e.encodeValue(path, rv.Elem())
+		}
+	}
+
+	switch rv.Type() {
+	case timeType:
+		return rv.Interface(), nil
+	case numberType:
+		return json.Number(rv.String()), nil
+	}
+	if rv.CanInterface() {
+		switch m := rv.Interface().(type) {
+		case json.Marshaler:
+			data, err := m.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+			var value interface{}
+			err = decoder.Decode(&value)
+			return value, err
+		case encoding.TextMarshaler:
+			text, err := m.MarshalText()
+			return string(text), err
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		v, err := e.enter(path, rv)
+		if err != nil {
+			return nil, err
+		}
+		defer delete(e.visiting, v)
+		return e.encodeValue(path, rv.Elem())
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(rv.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Number(strconv.FormatUint(rv.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("typed: unsupported value %v at %s", f, path)
+		}
+		bits := 64
+		if rv.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, bits)), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Struct:
+		return e.encodeStruct(path, rv)
+	case reflect.Map:
+		return e.encodeMap(path, rv)
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(rv.Bytes()), nil
+		}
+		v, err := e.enter(path, rv)
+		if err != nil {
+			return nil, err
+		}
+		defer delete(e.visiting, v)
+		return e.encodeElements(path, rv)
+	case reflect.Array:
+		return e.encodeElements(path, rv)
+	}
+	return nil, fmt.Errorf("typed: unsupported type %s at %s", rv.Type(), path)
+}
+
+func (e *encoder) encodeStruct(path string, rv reflect.Value) (interface{}, error) {
+	fields := structFields(rv.Type())
+	m := make(map[string]interface{}, len(fields))
+	for name, f := range fields {
+		fv, ok := fieldValue(rv, f.index)
+		if ok == false || (f.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+		value, err := e.encodeValue(joinPath(path, name), fv)
+		if err != nil {
+			return nil, err
+		}
+		m[name] = value
+	}
+	return m, nil
+}
+
+func (e *encoder) encodeMap(path string, rv reflect.Value) (interface{}, error) {
+	if rv.IsNil() {
+		return nil, nil
+	}
+	v, err := e.enter(path, rv)
+	if err != nil {
+		return nil, err
+	}
+	defer delete(e.visiting, v)
+	m := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := encodeKey(path, iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		value, err := e.encodeValue(joinPath(path, key), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		m[key] = value
+	}
+	return m, nil
+}
+
+func encodeKey(path string, key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if tm, ok := key.Interface().(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	case reflect.Interface:
+		if key.IsNil() == false {
+			return fmt.Sprint(key.Interface()), nil
+		}
+	}
+	return "", fmt.Errorf("typed: unsupported map key %s at %s", key.Type(), path)
+}
+
+func (e *encoder) encodeElements(path string, rv reflect.Value) (interface{}, error) {
+	a := make([]interface{}, rv.Len())
+	for i := range a {
+		value, err := e.encodeValue(fmt.Sprintf("%s[%d]", path, i), rv.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		a[i] = value
+	}
+	return a, nil
+}
+
+// Returns the nested field, or false if it's within a nil embedded pointer
+func fieldValue(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// The same definition of empty that encoding/json uses for omitempty
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return rv.Bool() == false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return rv.IsNil()
+	}
+	return false
+}
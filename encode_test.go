@@ -0,0 +1,174 @@
+package typed
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"testing"
+	"time"
+)
+
+type encodeBase struct {
+	ID int `json:"id"`
+}
+
+type EncodeExtra struct {
+	Extra string `json:"extra"`
+}
+
+type encodeUser struct {
+	encodeBase
+	*EncodeExtra
+	Name     string            `json:"name"`
+	Age      uint8             `json:"age"`
+	Score    float32           `json:"score"`
+	Admin    bool              `json:"admin"`
+	Nickname string            `json:"nickname,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Created  time.Time         `json:"created"`
+	IP       net.IP            `json:"ip"`
+	Raw      json.RawMessage   `json:"raw"`
+	Data     []byte            `json:"data"`
+	Matrix   [][]int           `json:"matrix"`
+	Labels   map[string]string `json:"labels"`
+	Parent   *encodeUser       `json:"parent"`
+	Ignored  string            `json:"-"`
+	Plain    string
+	hidden   string
+}
+
+func Test_FromStruct(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := &encodeUser{
+		encodeBase: encodeBase{ID: 9},
+		Name:       "leto",
+		Age:        200,
+		Score:      1.5,
+		Created:    created,
+		IP:         net.ParseIP("127.0.0.1"),
+		Raw:        json.RawMessage(`{"x": 1}`),
+		Data:       []byte("hi"),
+		Matrix:     [][]int{{1, 2}, {3}},
+		Labels:     map[string]string{"a": "b"},
+		Parent:     &encodeUser{Name: "paul", EncodeExtra: &EncodeExtra{Extra: "x"}},
+		Ignored:    "no",
+		Plain:      "yes",
+		hidden:     "no",
+	}
+
+	typed, err := FromStruct(user)
+	equal(t, err, nil)
+	equal(t, typed["id"], json.Number("9"))
+	equal(t, typed.Int("id"), 9)
+	equal(t, typed.String("name"), "leto")
+	equal(t, typed.Int("age"), 200)
+	equal(t, typed.Float("score"), 1.5)
+	equal(t, typed.Bool("admin"), false)
+	equal(t, typed.Exists("nickname"), false)
+	equal(t, typed.Exists("tags"), false)
+	equal(t, typed.Exists("extra"), false)
+	equal(t, typed.Time("created"), created)
+	equal(t, typed.String("ip"), "127.0.0.1")
+	equal(t, typed.IntPath("raw.x"), 1)
+	equal(t, typed.String("data"), "aGk=")
+	equal(t, typed.Ints64Path("matrix[0]")[1], int64(2))
+	equal(t, typed.StringPath("labels.a"), "b")
+	equal(t, typed.StringPath("parent.name"), "paul")
+	equal(t, typed.StringPath("parent.extra"), "x")
+	equal(t, typed.InterfacePath("parent.parent"), nil)
+	equal(t, typed.Exists("Ignored"), false)
+	equal(t, typed.String("Plain"), "yes")
+	equal(t, typed.Exists("hidden"), false)
+	_, ok := typed["labels"].(map[string]interface{})
+	equal(t, ok, true)
+	_, ok = typed["matrix"].([]interface{})
+	equal(t, ok, true)
+
+	var decoded encodeUser
+	equal(t, typed.DecodeAll(&decoded), nil)
+	equal(t, decoded.Name, "leto")
+	equal(t, decoded.Parent.Extra, "x")
+	equal(t, string(decoded.Data), "hi")
+}
+
+func Test_FromStructMaps(t *testing.T) {
+	typed, err := FromStruct(map[string]string{"a": "b"})
+	equal(t, err, nil)
+	equal(t, typed.String("a"), "b")
+
+	typed, err = FromStruct(map[interface{}]interface{}{"a": map[interface{}]interface{}{1: "one"}, 2: []interface{}{true, false}})
+	equal(t, err, nil)
+	equal(t, typed.StringPath("a.1"), "one")
+	equalList(t, typed.Bools("2"), []bool{true, false})
+
+	typed, err = FromStruct(map[int]float64{1: 0.25})
+	equal(t, err, nil)
+	equal(t, typed.Float("1"), 0.25)
+
+	typed, err = FromStruct(Typed(build("a", []int{1}, "b", build("c", int64(2)))))
+	equal(t, err, nil)
+	equal(t, typed["a"].([]interface{})[0], json.Number("1"))
+	equal(t, typed.IntPath("b.c"), 2)
+
+	typed, err = FromStruct(struct {
+		ID json.Number `json:"id"`
+	}{json.Number("12345678901234567890")})
+	equal(t, err, nil)
+	equal(t, typed["id"], interface{}(json.Number("12345678901234567890")))
+	equal(t, typed.BigInt("id").String(), "12345678901234567890")
+}
+
+type encodeNode struct {
+	Name string      `json:"name"`
+	Next *encodeNode `json:"next"`
+}
+
+func Test_FromStructCycles(t *testing.T) {
+	node := &encodeNode{Name: "a"}
+	node.Next = &encodeNode{Name: "b", Next: node}
+	_, err := FromStruct(node)
+	equal(t, err.Error(), "typed: cycle through *typed.encodeNode at next.next")
+
+	m := map[string]interface{}{}
+	m["self"] = m
+	_, err = FromStruct(m)
+	equal(t, err.Error(), "typed: cycle through map[string]interface {} at self")
+
+	a := []interface{}{nil}
+	a[0] = a
+	_, err = FromStruct(map[string]interface{}{"a": a})
+	equal(t, err.Error(), "typed: cycle through []interface {} at a[0]")
+
+	// the same value twice isn't a cycle
+	shared := &encodeNode{Name: "shared"}
+	typed, err := FromStruct(map[string]interface{}{"a": shared, "b": shared, "c": []*encodeNode{shared, shared}})
+	equal(t, err, nil)
+	equal(t, typed.StringPath("c[1].name"), "shared")
+}
+
+func Test_FromStructRecursiveEmbedding(t *testing.T) {
+	typed, err := FromStruct(&DecodeNode{Name: "leto", DecodeNode: &DecodeNode{Name: "paul"}})
+	equal(t, err, nil)
+	equalList(t, typed, map[string]interface{}{"name": "leto"})
+
+	typed, err = FromStruct(DecodeLeft{Left: 1, DecodeRight: &DecodeRight{Right: 2}})
+	equal(t, err, nil)
+	equalList(t, typed, map[string]interface{}{"left": 1, "right": 2})
+}
+
+func Test_FromStructErrors(t *testing.T) {
+	_, err := FromStruct([]int{1})
+	equal(t, err.Error(), "typed: cannot create a Typed from []int")
+
+	_, err = FromStruct(nil)
+	equal(t, err.Error(), "typed: cannot create a Typed from <nil>")
+
+	_, err = FromStruct(map[string]interface{}{"a": []interface{}{math.NaN()}})
+	equal(t, err.Error(), "typed: unsupported value NaN at a[0]")
+
+	_, err = FromStruct(map[string]interface{}{"a": make(chan int)})
+	equal(t, err.Error(), "typed: unsupported type chan int at a")
+
+	_, err = FromStruct(map[float64]int{1.5: 1})
+	equal(t, err.Error(), "typed: unsupported map key float64 at ")
+}
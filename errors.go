@@ -0,0 +1,47 @@
+package typed
+
+// Returned by the E accessors (e.g. IntE) and the value the Must
+// accessors panic with when a value is missing or can't be converted
+type Error struct {
+	// The key, path or JSON Pointer which was read
+	Key string
+
+	// The type the accessor expected (e.g. "int", "string", "map"),
+	// empty for the Interface accessors, which accept any value
+	Expected string
+
+	// The JSON type of the value ("null", "object", "array", "number",
+	// "boolean" or "string") or "missing" if it doesn't exist
+	Actual string
+}
+
+func (e *Error) Error() string {
+	// the messages of InterfaceMust and ObjectMust predate Error
+	if e.Expected == "" || e.Expected == "map" {
+		return "expected map for " + e.Key
+	}
+	return "expected " + e.Expected + " value for " + e.Key
+}
+
+func newError(key string, expected string, value interface{}, exists bool) *Error {
+	actual := "missing"
+	if exists {
+		actual = jsonType(value)
+	}
+	return &Error{Key: key, Expected: expected, Actual: actual}
+}
+
+func (t Typed) keyError(key string, expected string) *Error {
+	value, exists := t[key]
+	return newError(key, expected, value, exists)
+}
+
+func (t Typed) pathError(path string, expected string) *Error {
+	value, exists := t.lookup(path)
+	return newError(path, expected, value, exists)
+}
+
+func (t Typed) pointerError(pointer string, expected string) *Error {
+	value, exists := t.resolve(pointer)
+	return newError(pointer, expected, value, exists)
+}
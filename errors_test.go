@@ -0,0 +1,91 @@
+package typed
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_E(t *testing.T) {
+	typed, _ := JsonString(`{"port": 80, "host": "localhost", "server": {"port": 81}, "servers": [{"port": 82}], "timeout": "1s", "id": 9007199254740993, "price": 1.50, "nope": null}`)
+	port, err := typed.IntE("port")
+	equal(t, port, 80)
+	equal(t, err, nil)
+	host, err := typed.StringE("host")
+	equal(t, host, "localhost")
+	equal(t, err, nil)
+	server, err := typed.ObjectE("server")
+	equal(t, server.Int("port"), 81)
+	equal(t, err, nil)
+	servers, err := typed.ObjectsE("servers")
+	equal(t, servers[0].Int("port"), 82)
+	equal(t, err, nil)
+	equal(t, valid(typed.DurationE("timeout")), true)
+	equal(t, valid(typed.Int64E("id")), true)
+	equal(t, valid(typed.DecimalE("price")), true)
+	equal(t, valid(typed.InterfaceE("nope")), true)
+
+	_, err = typed.IntE("host")
+	assertError(t, err, "host", "int", "string")
+	_, err = typed.BoolE("other")
+	assertError(t, err, "other", "boolean", "missing")
+	_, err = typed.ObjectE("port")
+	assertError(t, err, "port", "map", "number")
+	_, err = typed.ObjectsE("server")
+	assertError(t, err, "server", "objects", "object")
+	_, err = typed.Uint8E("nope")
+	assertError(t, err, "nope", "uint8", "null")
+	_, err = typed.InterfaceE("other")
+	assertError(t, err, "other", "", "missing")
+	equal(t, err.Error(), "expected map for other")
+	_, err = typed.TimeE("host")
+	equal(t, err.Error(), "expected time.Time value for host")
+}
+
+func Test_MustPanicsWithError(t *testing.T) {
+	typed, _ := JsonString(`{"server": {"port": "eighty"}, "servers": [1]}`)
+	cases := []struct {
+		fn       func()
+		key      string
+		expected string
+		actual   string
+	}{
+		{func() { typed.IntMust("server") }, "server", "int", "object"},
+		{func() { typed.IntPathMust("server.port") }, "server.port", "int", "string"},
+		{func() { typed.StringPointerMust("/servers/0") }, "/servers/0", "string", "number"},
+		{func() { typed.ObjectPathMust("server.other") }, "server.other", "map", "missing"},
+		{func() { typed.WithPolicy(Strict()).FloatMust("server") }, "server", "float", "object"},
+	}
+	for _, c := range cases {
+		func() {
+			defer func() {
+				err, ok := recover().(*Error)
+				equal(t, ok, true)
+				assertError(t, err, c.key, c.expected, c.actual)
+			}()
+			c.fn()
+			t.FailNow()
+		}()
+	}
+}
+
+func Test_CoercedE(t *testing.T) {
+	typed := New(build("port", "80"))
+	_, err := typed.WithPolicy(Strict()).IntE("port")
+	assertError(t, err, "port", "int", "string")
+	port, err := typed.WithPolicy(Standard()).IntE("port")
+	equal(t, port, 80)
+	equal(t, err, nil)
+}
+
+func assertError(t *testing.T, err error, key string, expected string, actual string) {
+	t.Helper()
+	var e *Error
+	equal(t, errors.As(err, &e), true)
+	equal(t, e.Key, key)
+	equal(t, e.Expected, expected)
+	equal(t, e.Actual, actual)
+}
+
+func valid(_ interface{}, err error) bool {
+	return err == nil
+}
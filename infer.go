@@ -0,0 +1,204 @@
+package typed
+
+import (
+	"sort"
+)
+
+// The maximum number of distinct values a string field can have
+// to be inferred as an enum, unless Inference.EnumLimit is called
+const defaultEnumLimit = 10
+
+// The order in which the types of a value are listed
+var inferTypes = []string{"object", "array", "string", "integer", "number", "boolean", "null"}
+
+// Accumulates the shape of sample documents to infer a JSON
+// Schema (see InferSchema). Useful when the samples don't fit in
+// memory, e.g. when reading them one line at a time from NDJSON.
+type Inference struct {
+	root      *shape
+	enumLimit int
+}
+
+// The shape of every value seen at a location
+type shape struct {
+	types map[string]bool
+
+	// the distinct strings, nil once there are more than the enum limit
+	strings     map[string]bool
+	stringCount int
+
+	objects    int
+	properties map[string]*shape
+	// the number of objects each property was seen in
+	present map[string]int
+
+	items *shape
+}
+
+func newShape() *shape {
+	return &shape{types: make(map[string]bool), strings: make(map[string]bool)}
+}
+
+// Creates an empty Inference
+func NewInference() *Inference {
+	return &Inference{root: newShape(), enumLimit: defaultEnumLimit}
+}
+
+// Sets the maximum number of distinct values a string field can
+// have to be inferred as an enum (10 by default). Must be called
+// before Add
+func (i *Inference) EnumLimit(n int) *Inference {
+	i.enumLimit = n
+	return i
+}
+
+// Adds sample documents
+func (i *Inference) Add(samples ...Typed) {
+	for _, sample := range samples {
+		i.root.add(map[string]interface{}(sample), i.enumLimit)
+	}
+}
+
+// Returns the JSON Schema (draft 2020-12) inferred from the samples
+func (i *Inference) Schema() Typed {
+	schema := i.root.schema()
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	return Typed(schema)
+}
+
+// Infers a JSON Schema (draft 2020-12) from sample documents:
+//   - the type of each value; fields which are sometimes null have
+//     a type like ["string", "null"]
+//   - the fields present in every sample are required
+//   - strings with few distinct values, each seen more than once,
+//     are an enum (see Inference.EnumLimit)
+//   - the elements of arrays are merged into a single items schema
+func InferSchema(samples ...Typed) Typed {
+	i := NewInference()
+	i.Add(samples...)
+	return i.Schema()
+}
+
+func (s *shape) add(value interface{}, enumLimit int) {
+	t := jsonType(value)
+	switch t {
+	case "number":
+		if isInteger(value) {
+			t = "integer"
+		}
+	case "string":
+		s.stringCount++
+		if s.strings != nil {
+			s.strings[value.(string)] = true
+			if len(s.strings) > enumLimit {
+				s.strings = nil
+			}
+		}
+	case "object":
+		o, _ := toObject(value)
+		s.addObject(o, enumLimit)
+	case "array":
+		a, _ := toArray(value)
+		if s.items == nil {
+			s.items = newShape()
+		}
+		for _, element := range a {
+			s.items.add(element, enumLimit)
+		}
+	}
+	s.types[t] = true
+}
+
+func (s *shape) addObject(o Typed, enumLimit int) {
+	if s.properties == nil {
+		s.properties = make(map[string]*shape)
+		s.present = make(map[string]int)
+	}
+	s.objects++
+	for key, value := range o {
+		property, exists := s.properties[key]
+		if exists == false {
+			property = newShape()
+			s.properties[key] = property
+		}
+		property.add(value, enumLimit)
+		s.present[key]++
+	}
+}
+
+func (s *shape) schema() map[string]interface{} {
+	schema := make(map[string]interface{})
+
+	var types []interface{}
+	for _, t := range inferTypes {
+		// integers are numbers, no need to list both
+		if s.types[t] && (t != "integer" || s.types["number"] == false) {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 1 {
+		schema["type"] = types[0]
+	} else if len(types) > 1 {
+		schema["type"] = types
+	}
+
+	if s.objects > 0 {
+		properties := make(map[string]interface{}, len(s.properties))
+		var required []interface{}
+		for _, key := range sortedShapeKeys(s.properties) {
+			properties[key] = s.properties[key].schema()
+			if s.present[key] == s.objects {
+				required = append(required, key)
+			}
+		}
+		schema["properties"] = properties
+		if required != nil {
+			schema["required"] = required
+		}
+	}
+
+	if s.items != nil && len(s.items.types) > 0 {
+		schema["items"] = s.items.schema()
+	}
+
+	if enum := s.enum(); enum != nil {
+		schema["enum"] = enum
+	}
+	return schema
+}
+
+// Returns the distinct strings if the value looks like an enum:
+// only ever a string (or null), with few distinct values each
+// seen more than once on average
+func (s *shape) enum() []interface{} {
+	if len(s.strings) == 0 || s.stringCount < 2*len(s.strings) {
+		return nil
+	}
+	for t := range s.types {
+		if t != "string" && t != "null" {
+			return nil
+		}
+	}
+	values := make([]string, 0, len(s.strings))
+	for value := range s.strings {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	enum := make([]interface{}, 0, len(values)+1)
+	for _, value := range values {
+		enum = append(enum, value)
+	}
+	if s.types["null"] {
+		enum = append(enum, nil)
+	}
+	return enum
+}
+
+func sortedShapeKeys(m map[string]*shape) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
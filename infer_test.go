@@ -0,0 +1,98 @@
+package typed
+
+import (
+	"testing"
+)
+
+func Test_InferSchema(t *testing.T) {
+	samples, _ := JsonStringArray(`[
+		{"id": 1, "name": "leto", "role": "admin", "score": 1.5, "tags": ["a"], "server": {"host": "a", "port": 80}, "nick": null},
+		{"id": 2, "name": "paul", "role": "user", "score": 2, "tags": [], "server": {"host": "b"}, "nick": "usul", "active": true},
+		{"id": 3, "name": "jessica", "role": "user", "score": 3, "tags": ["b", 1], "server": {"host": "c", "port": 81}},
+		{"id": 4, "name": "ghanima", "role": "admin", "tags": null, "servers": [{"host": "d", "port": 1}, {"host": "e"}]}
+	]`)
+	schema := InferSchema(samples...)
+	equalList(t, schema, map[string]interface{}{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"type":     "object",
+		"required": []interface{}{"id", "name", "role", "tags"},
+		"properties": map[string]interface{}{
+			"active": map[string]interface{}{"type": "boolean"},
+			"id":     map[string]interface{}{"type": "integer"},
+			"name":   map[string]interface{}{"type": "string"},
+			"nick":   map[string]interface{}{"type": []interface{}{"string", "null"}},
+			"role":   map[string]interface{}{"type": "string", "enum": []interface{}{"admin", "user"}},
+			"score":  map[string]interface{}{"type": "number"},
+			"tags": map[string]interface{}{
+				"type":  []interface{}{"array", "null"},
+				"items": map[string]interface{}{"type": []interface{}{"string", "integer"}},
+			},
+			"server": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"host"},
+				"properties": map[string]interface{}{
+					"host": map[string]interface{}{"type": "string"},
+					"port": map[string]interface{}{"type": "integer"},
+				},
+			},
+			"servers": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type":     "object",
+					"required": []interface{}{"host"},
+					"properties": map[string]interface{}{
+						"host": map[string]interface{}{"type": "string"},
+						"port": map[string]interface{}{"type": "integer"},
+					},
+				},
+			},
+		},
+	})
+
+	// the samples are valid against the inferred schema
+	s, err := CompileSchema(schema)
+	equal(t, err, nil)
+	for _, sample := range samples {
+		equal(t, s.Valid(sample), true)
+	}
+}
+
+func Test_InferSchemaEnum(t *testing.T) {
+	i := NewInference()
+	i.Add(New(build("level", "low")), New(build("level", "high")), New(build("level", "low")))
+	i.Add(New(build("level", nil)), New(build("level", "high")))
+	equalList(t, i.Schema().Object("properties").Object("level"), map[string]interface{}{
+		"type": []interface{}{"string", "null"},
+		"enum": []interface{}{"high", "low", nil},
+	})
+
+	// every value is distinct
+	schema := InferSchema(New(build("name", "leto")), New(build("name", "paul")))
+	equalList(t, schema.Object("properties").Object("name"), map[string]interface{}{"type": "string"})
+
+	// too many distinct values
+	samples := []Typed{New(build("a", "x")), New(build("a", "y")), New(build("a", "x")), New(build("a", "y"))}
+	i = NewInference().EnumLimit(1)
+	i.Add(samples...)
+	equalList(t, i.Schema().Object("properties").Object("a"), map[string]interface{}{"type": "string"})
+	equalList(t, InferSchema(samples...).Object("properties").Object("a")["enum"], []interface{}{"x", "y"})
+
+	// only null
+	schema = InferSchema(New(build("a", nil)))
+	equalList(t, schema.Object("properties").Object("a"), map[string]interface{}{"type": "null"})
+}
+
+func Test_InferSchemaEmpty(t *testing.T) {
+	equalList(t, InferSchema(), map[string]interface{}{"$schema": "https://json-schema.org/draft/2020-12/schema"})
+	schema := InferSchema(New(build("a", []interface{}{})))
+	equalList(t, schema.Object("properties").Object("a"), map[string]interface{}{"type": "array"})
+}
+
+func Test_InferSchemaHugeExponents(t *testing.T) {
+	a, _ := JsonString(`{"int": 1e2000, "number": 1e-2000, "mixed": 2E+1000000000}`)
+	b, _ := JsonString(`{"int": -3e1001, "number": 1, "mixed": 0.5e-1001}`)
+	properties := InferSchema(a, b).Object("properties")
+	equal(t, properties.Object("int").String("type"), "integer")
+	equal(t, properties.Object("number").String("type"), "number")
+	equal(t, properties.Object("mixed").String("type"), "number")
+}
@@ -0,0 +1,950 @@
+package typed
+
+import (
+	"math"
+)
+
+// Returns an int8 at the key, or 0 if it doesn't
+// exist or isn't an int8
+func (t Typed) Int8(key string) int8 {
+	return t.Int8Or(key, 0)
+}
+
+// Returns an int8 at the key, or the specified
+// value if it doesn't exist or isn't an int8
+func (t Typed) Int8Or(key string, d int8) int8 {
+	if value, exists := t.Int8If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an int8 or panics
+func (t Typed) Int8Must(key string) int8 {
+	i, exists := t.Int8If(key)
+	if exists == false {
+		panic(t.keyError(key, "int8"))
+	}
+	return i
+}
+
+// Returns an int8 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) Int8E(key string) (int8, error) {
+	i, exists := t.Int8If(key)
+	if exists == false {
+		return i, t.keyError(key, "int8")
+	}
+	return i, nil
+}
+
+// Returns an int8 at the key and whether or not the key existed
+// and the value was an integer which fits in an int8
+func (t Typed) Int8If(key string) (int8, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toInt8(value)
+}
+
+func toInt8(value interface{}) (int8, bool) {
+	i, ok := toIntRange(value, math.MinInt8, math.MaxInt8)
+	return int8(i), ok
+}
+
+// Returns an slice of int8, or a nil slice
+func (t Typed) Ints8(key string) []int8 {
+	return t.Ints8Or(key, nil)
+}
+
+// Returns an slice of int8, or the specified slice
+// if the key doesn't exist or isn't a valid []int8
+func (t Typed) Ints8Or(key string, d []int8) []int8 {
+	n, ok := t.Ints8If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns an int8 slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid int8)
+func (t Typed) Ints8If(key string) ([]int8, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toInts8(value)
+}
+
+func toInts8(value interface{}) ([]int8, bool) {
+	if n, ok := value.([]int8); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]int8, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toInt8(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an map[string]int8
+// (returns nil if one of the values is not a valid int8)
+func (t Typed) StringInt8(key string) map[string]int8 {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int8, len(raw))
+	for k, value := range raw {
+		i, ok := toInt8(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
+
+// Returns an int16 at the key, or 0 if it doesn't
+// exist or isn't an int16
+func (t Typed) Int16(key string) int16 {
+	return t.Int16Or(key, 0)
+}
+
+// Returns an int16 at the key, or the specified
+// value if it doesn't exist or isn't an int16
+func (t Typed) Int16Or(key string, d int16) int16 {
+	if value, exists := t.Int16If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an int16 or panics
+func (t Typed) Int16Must(key string) int16 {
+	i, exists := t.Int16If(key)
+	if exists == false {
+		panic(t.keyError(key, "int16"))
+	}
+	return i
+}
+
+// Returns an int16 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) Int16E(key string) (int16, error) {
+	i, exists := t.Int16If(key)
+	if exists == false {
+		return i, t.keyError(key, "int16")
+	}
+	return i, nil
+}
+
+// Returns an int16 at the key and whether or not the key existed
+// and the value was an integer which fits in an int16
+func (t Typed) Int16If(key string) (int16, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toInt16(value)
+}
+
+func toInt16(value interface{}) (int16, bool) {
+	i, ok := toIntRange(value, math.MinInt16, math.MaxInt16)
+	return int16(i), ok
+}
+
+// Returns an slice of int16, or a nil slice
+func (t Typed) Ints16(key string) []int16 {
+	return t.Ints16Or(key, nil)
+}
+
+// Returns an slice of int16, or the specified slice
+// if the key doesn't exist or isn't a valid []int16
+func (t Typed) Ints16Or(key string, d []int16) []int16 {
+	n, ok := t.Ints16If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns an int16 slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid int16)
+func (t Typed) Ints16If(key string) ([]int16, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toInts16(value)
+}
+
+func toInts16(value interface{}) ([]int16, bool) {
+	if n, ok := value.([]int16); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]int16, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toInt16(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an map[string]int16
+// (returns nil if one of the values is not a valid int16)
+func (t Typed) StringInt16(key string) map[string]int16 {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int16, len(raw))
+	for k, value := range raw {
+		i, ok := toInt16(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
+
+// Returns an int32 at the key, or 0 if it doesn't
+// exist or isn't an int32
+func (t Typed) Int32(key string) int32 {
+	return t.Int32Or(key, 0)
+}
+
+// Returns an int32 at the key, or the specified
+// value if it doesn't exist or isn't an int32
+func (t Typed) Int32Or(key string, d int32) int32 {
+	if value, exists := t.Int32If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an int32 or panics
+func (t Typed) Int32Must(key string) int32 {
+	i, exists := t.Int32If(key)
+	if exists == false {
+		panic(t.keyError(key, "int32"))
+	}
+	return i
+}
+
+// Returns an int32 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) Int32E(key string) (int32, error) {
+	i, exists := t.Int32If(key)
+	if exists == false {
+		return i, t.keyError(key, "int32")
+	}
+	return i, nil
+}
+
+// Returns an int32 at the key and whether or not the key existed
+// and the value was an integer which fits in an int32
+func (t Typed) Int32If(key string) (int32, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toInt32(value)
+}
+
+func toInt32(value interface{}) (int32, bool) {
+	i, ok := toIntRange(value, math.MinInt32, math.MaxInt32)
+	return int32(i), ok
+}
+
+// Returns an slice of int32, or a nil slice
+func (t Typed) Ints32(key string) []int32 {
+	return t.Ints32Or(key, nil)
+}
+
+// Returns an slice of int32, or the specified slice
+// if the key doesn't exist or isn't a valid []int32
+func (t Typed) Ints32Or(key string, d []int32) []int32 {
+	n, ok := t.Ints32If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns an int32 slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid int32)
+func (t Typed) Ints32If(key string) ([]int32, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toInts32(value)
+}
+
+func toInts32(value interface{}) ([]int32, bool) {
+	if n, ok := value.([]int32); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]int32, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toInt32(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an map[string]int32
+// (returns nil if one of the values is not a valid int32)
+func (t Typed) StringInt32(key string) map[string]int32 {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int32, len(raw))
+	for k, value := range raw {
+		i, ok := toInt32(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
+
+// Returns an int64 at the key, or 0 if it doesn't
+// exist or isn't an int64
+func (t Typed) Int64(key string) int64 {
+	return t.Int64Or(key, 0)
+}
+
+// Returns an int64 at the key, or the specified
+// value if it doesn't exist or isn't an int64
+func (t Typed) Int64Or(key string, d int64) int64 {
+	if value, exists := t.Int64If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an int64 or panics
+func (t Typed) Int64Must(key string) int64 {
+	i, exists := t.Int64If(key)
+	if exists == false {
+		panic(t.keyError(key, "int64"))
+	}
+	return i
+}
+
+// Returns an int64 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) Int64E(key string) (int64, error) {
+	i, exists := t.Int64If(key)
+	if exists == false {
+		return i, t.keyError(key, "int64")
+	}
+	return i, nil
+}
+
+// Returns an int64 at the key and whether or not the key existed
+// and the value was an integer which fits in an int64
+func (t Typed) Int64If(key string) (int64, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toInt64(value)
+}
+
+// Returns an map[string]int64
+// (returns nil if one of the values is not a valid int64)
+func (t Typed) StringInt64(key string) map[string]int64 {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]int64, len(raw))
+	for k, value := range raw {
+		i, ok := toInt64(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
+
+// Returns an uint at the key, or 0 if it doesn't
+// exist or isn't an uint
+func (t Typed) Uint(key string) uint {
+	return t.UintOr(key, 0)
+}
+
+// Returns an uint at the key, or the specified
+// value if it doesn't exist or isn't an uint
+func (t Typed) UintOr(key string, d uint) uint {
+	if value, exists := t.UintIf(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an uint or panics
+func (t Typed) UintMust(key string) uint {
+	i, exists := t.UintIf(key)
+	if exists == false {
+		panic(t.keyError(key, "uint"))
+	}
+	return i
+}
+
+// Returns an uint or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) UintE(key string) (uint, error) {
+	i, exists := t.UintIf(key)
+	if exists == false {
+		return i, t.keyError(key, "uint")
+	}
+	return i, nil
+}
+
+// Returns an uint at the key and whether or not the key existed
+// and the value was an integer which fits in an uint
+func (t Typed) UintIf(key string) (uint, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toUint(value)
+}
+
+func toUint(value interface{}) (uint, bool) {
+	i, ok := toUintRange(value, maxUint)
+	return uint(i), ok
+}
+
+// Returns an slice of uint, or a nil slice
+func (t Typed) Uints(key string) []uint {
+	return t.UintsOr(key, nil)
+}
+
+// Returns an slice of uint, or the specified slice
+// if the key doesn't exist or isn't a valid []uint
+func (t Typed) UintsOr(key string, d []uint) []uint {
+	n, ok := t.UintsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns an uint slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid uint)
+func (t Typed) UintsIf(key string) ([]uint, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toUints(value)
+}
+
+func toUints(value interface{}) ([]uint, bool) {
+	if n, ok := value.([]uint); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]uint, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toUint(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an map[string]uint
+// (returns nil if one of the values is not a valid uint)
+func (t Typed) StringUint(key string) map[string]uint {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint, len(raw))
+	for k, value := range raw {
+		i, ok := toUint(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
+
+// Returns an uint8 at the key, or 0 if it doesn't
+// exist or isn't an uint8
+func (t Typed) Uint8(key string) uint8 {
+	return t.Uint8Or(key, 0)
+}
+
+// Returns an uint8 at the key, or the specified
+// value if it doesn't exist or isn't an uint8
+func (t Typed) Uint8Or(key string, d uint8) uint8 {
+	if value, exists := t.Uint8If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an uint8 or panics
+func (t Typed) Uint8Must(key string) uint8 {
+	i, exists := t.Uint8If(key)
+	if exists == false {
+		panic(t.keyError(key, "uint8"))
+	}
+	return i
+}
+
+// Returns an uint8 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) Uint8E(key string) (uint8, error) {
+	i, exists := t.Uint8If(key)
+	if exists == false {
+		return i, t.keyError(key, "uint8")
+	}
+	return i, nil
+}
+
+// Returns an uint8 at the key and whether or not the key existed
+// and the value was an integer which fits in an uint8
+func (t Typed) Uint8If(key string) (uint8, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toUint8(value)
+}
+
+func toUint8(value interface{}) (uint8, bool) {
+	i, ok := toUintRange(value, math.MaxUint8)
+	return uint8(i), ok
+}
+
+// Returns an slice of uint8, or a nil slice
+func (t Typed) Uints8(key string) []uint8 {
+	return t.Uints8Or(key, nil)
+}
+
+// Returns an slice of uint8, or the specified slice
+// if the key doesn't exist or isn't a valid []uint8
+func (t Typed) Uints8Or(key string, d []uint8) []uint8 {
+	n, ok := t.Uints8If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns an uint8 slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid uint8)
+func (t Typed) Uints8If(key string) ([]uint8, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toUints8(value)
+}
+
+func toUints8(value interface{}) ([]uint8, bool) {
+	if n, ok := value.([]uint8); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]uint8, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toUint8(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an map[string]uint8
+// (returns nil if one of the values is not a valid uint8)
+func (t Typed) StringUint8(key string) map[string]uint8 {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint8, len(raw))
+	for k, value := range raw {
+		i, ok := toUint8(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
+
+// Returns an uint16 at the key, or 0 if it doesn't
+// exist or isn't an uint16
+func (t Typed) Uint16(key string) uint16 {
+	return t.Uint16Or(key, 0)
+}
+
+// Returns an uint16 at the key, or the specified
+// value if it doesn't exist or isn't an uint16
+func (t Typed) Uint16Or(key string, d uint16) uint16 {
+	if value, exists := t.Uint16If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an uint16 or panics
+func (t Typed) Uint16Must(key string) uint16 {
+	i, exists := t.Uint16If(key)
+	if exists == false {
+		panic(t.keyError(key, "uint16"))
+	}
+	return i
+}
+
+// Returns an uint16 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) Uint16E(key string) (uint16, error) {
+	i, exists := t.Uint16If(key)
+	if exists == false {
+		return i, t.keyError(key, "uint16")
+	}
+	return i, nil
+}
+
+// Returns an uint16 at the key and whether or not the key existed
+// and the value was an integer which fits in an uint16
+func (t Typed) Uint16If(key string) (uint16, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toUint16(value)
+}
+
+func toUint16(value interface{}) (uint16, bool) {
+	i, ok := toUintRange(value, math.MaxUint16)
+	return uint16(i), ok
+}
+
+// Returns an slice of uint16, or a nil slice
+func (t Typed) Uints16(key string) []uint16 {
+	return t.Uints16Or(key, nil)
+}
+
+// Returns an slice of uint16, or the specified slice
+// if the key doesn't exist or isn't a valid []uint16
+func (t Typed) Uints16Or(key string, d []uint16) []uint16 {
+	n, ok := t.Uints16If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns an uint16 slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid uint16)
+func (t Typed) Uints16If(key string) ([]uint16, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toUints16(value)
+}
+
+func toUints16(value interface{}) ([]uint16, bool) {
+	if n, ok := value.([]uint16); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]uint16, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toUint16(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an map[string]uint16
+// (returns nil if one of the values is not a valid uint16)
+func (t Typed) StringUint16(key string) map[string]uint16 {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint16, len(raw))
+	for k, value := range raw {
+		i, ok := toUint16(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
+
+// Returns an uint32 at the key, or 0 if it doesn't
+// exist or isn't an uint32
+func (t Typed) Uint32(key string) uint32 {
+	return t.Uint32Or(key, 0)
+}
+
+// Returns an uint32 at the key, or the specified
+// value if it doesn't exist or isn't an uint32
+func (t Typed) Uint32Or(key string, d uint32) uint32 {
+	if value, exists := t.Uint32If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an uint32 or panics
+func (t Typed) Uint32Must(key string) uint32 {
+	i, exists := t.Uint32If(key)
+	if exists == false {
+		panic(t.keyError(key, "uint32"))
+	}
+	return i
+}
+
+// Returns an uint32 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) Uint32E(key string) (uint32, error) {
+	i, exists := t.Uint32If(key)
+	if exists == false {
+		return i, t.keyError(key, "uint32")
+	}
+	return i, nil
+}
+
+// Returns an uint32 at the key and whether or not the key existed
+// and the value was an integer which fits in an uint32
+func (t Typed) Uint32If(key string) (uint32, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toUint32(value)
+}
+
+func toUint32(value interface{}) (uint32, bool) {
+	i, ok := toUintRange(value, math.MaxUint32)
+	return uint32(i), ok
+}
+
+// Returns an slice of uint32, or a nil slice
+func (t Typed) Uints32(key string) []uint32 {
+	return t.Uints32Or(key, nil)
+}
+
+// Returns an slice of uint32, or the specified slice
+// if the key doesn't exist or isn't a valid []uint32
+func (t Typed) Uints32Or(key string, d []uint32) []uint32 {
+	n, ok := t.Uints32If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns an uint32 slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid uint32)
+func (t Typed) Uints32If(key string) ([]uint32, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toUints32(value)
+}
+
+func toUints32(value interface{}) ([]uint32, bool) {
+	if n, ok := value.([]uint32); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]uint32, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toUint32(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an map[string]uint32
+// (returns nil if one of the values is not a valid uint32)
+func (t Typed) StringUint32(key string) map[string]uint32 {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint32, len(raw))
+	for k, value := range raw {
+		i, ok := toUint32(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
+
+// Returns an uint64 at the key, or 0 if it doesn't
+// exist or isn't an uint64
+func (t Typed) Uint64(key string) uint64 {
+	return t.Uint64Or(key, 0)
+}
+
+// Returns an uint64 at the key, or the specified
+// value if it doesn't exist or isn't an uint64
+func (t Typed) Uint64Or(key string, d uint64) uint64 {
+	if value, exists := t.Uint64If(key); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an uint64 or panics
+func (t Typed) Uint64Must(key string) uint64 {
+	i, exists := t.Uint64If(key)
+	if exists == false {
+		panic(t.keyError(key, "uint64"))
+	}
+	return i
+}
+
+// Returns an uint64 or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) Uint64E(key string) (uint64, error) {
+	i, exists := t.Uint64If(key)
+	if exists == false {
+		return i, t.keyError(key, "uint64")
+	}
+	return i, nil
+}
+
+// Returns an uint64 at the key and whether or not the key existed
+// and the value was an integer which fits in an uint64
+func (t Typed) Uint64If(key string) (uint64, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return 0, false
+	}
+	return toUint64(value)
+}
+
+// Returns an slice of uint64, or a nil slice
+func (t Typed) Uints64(key string) []uint64 {
+	return t.Uints64Or(key, nil)
+}
+
+// Returns an slice of uint64, or the specified slice
+// if the key doesn't exist or isn't a valid []uint64
+func (t Typed) Uints64Or(key string, d []uint64) []uint64 {
+	n, ok := t.Uints64If(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns an uint64 slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid uint64)
+func (t Typed) Uints64If(key string) ([]uint64, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toUints64(value)
+}
+
+func toUints64(value interface{}) ([]uint64, bool) {
+	if n, ok := value.([]uint64); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]uint64, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toUint64(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an map[string]uint64
+// (returns nil if one of the values is not a valid uint64)
+func (t Typed) StringUint64(key string) map[string]uint64 {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil
+	}
+	m := make(map[string]uint64, len(raw))
+	for k, value := range raw {
+		i, ok := toUint64(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = i
+	}
+	return m
+}
@@ -0,0 +1,112 @@
+package typed
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_Int64(t *testing.T) {
+	typed := New(build("id", json.Number("9007199254740993"), "exp", json.Number("1e3"), "string", "-42", "f64", 3.0, "int", 7, "u64", uint64(8),
+		"frac", 3.7, "fracNumber", json.Number("3.7"), "huge", 1e20, "hugeNumber", json.Number("9223372036854775808"), "hugeU64", uint64(1<<63), "nope", true))
+	equal(t, typed.Int64("id"), int64(9007199254740993))
+	equal(t, typed.Int64("exp"), int64(1000))
+	equal(t, typed.Int64("string"), int64(-42))
+	equal(t, typed.Int64("f64"), int64(3))
+	equal(t, typed.Int64("int"), int64(7))
+	equal(t, typed.Int64("u64"), int64(8))
+	equal(t, typed.Int64Or("other", 9), int64(9))
+	equal(t, typed.Int64Must("id"), int64(9007199254740993))
+	for _, key := range []string{"frac", "fracNumber", "huge", "hugeNumber", "hugeU64", "nope", "other"} {
+		_, ok := typed.Int64If(key)
+		equal(t, ok, false)
+		equal(t, typed.Int64(key), int64(0))
+	}
+}
+
+func Test_HugeExponents(t *testing.T) {
+	typed := New(build("huge", json.Number("1e1000000000"), "tiny", json.Number("-1E-1000000000"), "limit", json.Number("1e1000")))
+	for _, key := range []string{"huge", "tiny"} {
+		_, ok := typed.Int64If(key)
+		equal(t, ok, false)
+		_, ok = typed.Uint64If(key)
+		equal(t, ok, false)
+		_, ok = typed.BigIntIf(key)
+		equal(t, ok, false)
+		_, ok = typed.DecimalIf(key)
+		equal(t, ok, false)
+	}
+	equal(t, len(typed.BigInt("limit").String()), 1001)
+}
+
+func Test_Uint64(t *testing.T) {
+	typed := New(build("max", json.Number("18446744073709551615"), "string", "42", "neg", -1, "negNumber", json.Number("-1"), "frac", 1.5, "over", json.Number("18446744073709551616")))
+	equal(t, typed.Uint64("max"), uint64(18446744073709551615))
+	equal(t, typed.Uint64("string"), uint64(42))
+	for _, key := range []string{"neg", "negNumber", "frac", "over"} {
+		_, ok := typed.Uint64If(key)
+		equal(t, ok, false)
+	}
+}
+
+func Test_IntWidths(t *testing.T) {
+	typed := New(build("n127", json.Number("127"), "n128", json.Number("128"), "n255", 255.0, "n256", 256, "neg", "-129", "big", int64(1<<40)))
+	equal(t, typed.Int8("n127"), int8(127))
+	equal(t, typed.Int8Or("n128", -1), int8(-1))
+	equal(t, typed.Int8Or("neg", 1), int8(1))
+	equal(t, typed.Int16("n128"), int16(128))
+	equal(t, typed.Int16("neg"), int16(-129))
+	equal(t, typed.Int32Or("big", 1), int32(1))
+	equal(t, typed.Uint8("n255"), uint8(255))
+	equal(t, typed.Uint8Or("n256", 1), uint8(1))
+	equal(t, typed.Uint8Or("neg", 1), uint8(1))
+	equal(t, typed.Uint16("n256"), uint16(256))
+	equal(t, typed.Uint32Or("big", 1), uint32(1))
+	equal(t, typed.Uint("big"), uint(1<<40))
+}
+
+func Test_IntWidthMust(t *testing.T) {
+	defer mustTest(t, "expected uint8 value for fail")
+	New(build("fail", 256)).Uint8Must("fail")
+	t.FailNow()
+}
+
+func Test_IntWidthSlices(t *testing.T) {
+	typed, _ := JsonString(`{"small": [1, "2", 3.0], "large": [1, 300], "neg": [1, -1], "frac": [1, 1.5], "empty": []}`)
+	equalList(t, typed.Ints8("small"), []int8{1, 2, 3})
+	equalList(t, typed.Uints8("small"), []uint8{1, 2, 3})
+	equalList(t, typed.Ints16("large"), []int16{1, 300})
+	equalList(t, typed.Ints32("neg"), []int32{1, -1})
+	equalList(t, typed.Uints("large"), []uint{1, 300})
+	equalList(t, typed.Uints16("large"), []uint16{1, 300})
+	equalList(t, typed.Uints32("large"), []uint32{1, 300})
+	equalList(t, typed.Uints64("large"), []uint64{1, 300})
+	equal(t, len(typed.Uints64("empty")), 0)
+	equalList(t, typed.Uints8Or("other", []uint8{9}), []uint8{9})
+
+	values, ok := typed.Ints8If("large")
+	equalList(t, values, []int8{1, 0})
+	equal(t, ok, false)
+	_, ok = typed.Uints32If("neg")
+	equal(t, ok, false)
+	_, ok = typed.Ints32If("frac")
+	equal(t, ok, false)
+	// Ints64 predates the fixed-width accessors and truncates like Ints
+	equalList(t, typed.Ints64("frac"), []int64{1, 1})
+	equal(t, New(build("native", []uint16{4})).Uints16("native")[0], uint16(4))
+}
+
+func Test_IntWidthMaps(t *testing.T) {
+	typed, _ := JsonString(`{"limits": {"a": 1, "b": "200"}, "neg": {"a": -1}, "bad": {"a": true}}`)
+	equal(t, typed.StringUint8("limits")["b"], uint8(200))
+	equal(t, typed.StringInt64("limits")["a"], int64(1))
+	equal(t, typed.StringInt16("neg")["a"], int16(-1))
+	equal(t, len(typed.StringInt8("limits")), 0)
+	equal(t, len(typed.StringUint("neg")), 0)
+	equal(t, len(typed.StringInt32("bad")), 0)
+	equal(t, len(typed.StringUint64("other")), 0)
+	equal(t, typed.StringUint16("limits")["b"], uint16(200))
+	equal(t, typed.StringUint32("limits")["b"], uint32(200))
+	equal(t, typed.StringUint64("limits")["b"], uint64(200))
+	equal(t, typed.StringUint("limits")["b"], uint(200))
+	equal(t, typed.StringInt32("limits")["b"], int32(200))
+}
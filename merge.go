@@ -0,0 +1,223 @@
+package typed
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// How two scalar values (or a scalar and null) at the same path are merged
+type ScalarStrategy int
+
+const (
+	// The value from the right (later) document is kept
+	RightWins ScalarStrategy = iota
+	// The value from the left (earlier) document is kept
+	LeftWins
+)
+
+// How two arrays at the same path are merged
+type ArrayStrategy int
+
+const (
+	// The array from the winning side (see ScalarStrategy) is kept
+	ArrayReplace ArrayStrategy = iota
+	// The elements of the right array are appended to the left array
+	ArrayAppend
+	// The elements of the right array which aren't in the left array
+	// are appended. When MergeOptions.UnionKey is set, objects which
+	// share the same value for that key are merged together
+	ArrayUnion
+)
+
+// How two values of different types (e.g. an object and a string)
+// at the same path are merged
+type ConflictStrategy int
+
+const (
+	// The value from the winning side (see ScalarStrategy) is kept
+	ConflictOverwrite ConflictStrategy = iota
+	// The merge fails with a *MergeError
+	ConflictError
+)
+
+type MergeOptions struct {
+	Scalars   ScalarStrategy
+	Arrays    ArrayStrategy
+	Conflicts ConflictStrategy
+	// Used by ArrayUnion to identify objects
+	UnionKey string
+}
+
+// Returned by Merge when two values of different types are found
+// at the same path and MergeOptions.Conflicts is ConflictError
+type MergeError struct {
+	Path  string
+	Left  interface{}
+	Right interface{}
+}
+
+func (e *MergeError) Error() string {
+	return fmt.Sprintf("cannot merge %s into %s at %s", jsonType(e.Right), jsonType(e.Left), e.Path)
+}
+
+// Recursively merges the documents, from left to right, into a new
+// Typed. Objects are always merged key by key, the options control
+// how scalars, arrays and type conflicts are handled. The given
+// documents are not modified and the result doesn't share any
+// object or array with them.
+func Merge(options MergeOptions, typeds ...Typed) (Typed, error) {
+	merged := Typed(make(map[string]interface{}))
+	for _, t := range typeds {
+		if err := options.mergeObject("", merged, t); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// Merges right into left (which is owned by the merge)
+func (o MergeOptions) mergeObject(path string, left map[string]interface{}, right map[string]interface{}) error {
+	for key, r := range right {
+		l, exists := left[key]
+		if exists == false {
+			left[key] = plain(r)
+			continue
+		}
+		value, err := o.merge(joinPath(path, key), l, r)
+		if err != nil {
+			return err
+		}
+		left[key] = value
+	}
+	return nil
+}
+
+func (o MergeOptions) merge(path string, left interface{}, right interface{}) (interface{}, error) {
+	lo, leftObject := toObject(left)
+	ro, rightObject := toObject(right)
+	if leftObject && rightObject {
+		return lo, o.mergeObject(path, lo, ro)
+	}
+
+	la, leftArray := toArray(left)
+	ra, rightArray := toArray(right)
+	if leftArray && rightArray {
+		return o.mergeArray(path, la, ra)
+	}
+
+	if left != nil && right != nil && o.Conflicts == ConflictError && jsonType(left) != jsonType(right) {
+		return nil, &MergeError{Path: path, Left: left, Right: right}
+	}
+	return o.winner(left, right), nil
+}
+
+func (o MergeOptions) mergeArray(path string, left []interface{}, right []interface{}) (interface{}, error) {
+	switch o.Arrays {
+	case ArrayAppend:
+		return append(left, plain(right).([]interface{})...), nil
+	case ArrayUnion:
+		return o.union(path, left, right)
+	}
+	return o.winner(left, right), nil
+}
+
+func (o MergeOptions) union(path string, left []interface{}, right []interface{}) (interface{}, error) {
+	for _, r := range right {
+		found := -1
+		for i, l := range left {
+			if o.sameElement(l, r) {
+				found = i
+				break
+			}
+		}
+		if found == -1 {
+			left = append(left, plain(r))
+			continue
+		}
+		if o.UnionKey != "" {
+			value, err := o.merge(fmt.Sprintf("%s[%d]", path, found), left[found], r)
+			if err != nil {
+				return nil, err
+			}
+			left[found] = value
+		}
+	}
+	return left, nil
+}
+
+// Whether the two array elements represent the same element
+// for the purpose of ArrayUnion
+func (o MergeOptions) sameElement(left interface{}, right interface{}) bool {
+	if o.UnionKey != "" {
+		lo, lok := toObject(left)
+		ro, rok := toObject(right)
+		if lok && rok {
+			lk, lexists := lo[o.UnionKey]
+			rk, rexists := ro[o.UnionKey]
+			return lexists && rexists && equalValues(lk, rk)
+		}
+	}
+	return equalValues(left, right)
+}
+
+// Left is always owned by the merge, right must be copied
+func (o MergeOptions) winner(left interface{}, right interface{}) interface{} {
+	if o.Scalars == LeftWins {
+		return left
+	}
+	return plain(right)
+}
+
+// Returns a deep copy of the value where every object is a
+// map[string]interface{} and every array is a []interface{}
+func plain(value interface{}) interface{} {
+	if o, ok := toObject(value); ok {
+		m := make(map[string]interface{}, len(o))
+		for k, v := range o {
+			m[k] = plain(v)
+		}
+		return m
+	}
+	if a, ok := toArray(value); ok {
+		c := make([]interface{}, len(a))
+		for i, v := range a {
+			c[i] = plain(v)
+		}
+		return c
+	}
+	return value
+}
+
+// Appends the key to a dotted path
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Returns the name of the JSON type of the value
+func jsonType(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	if _, ok := toObject(value); ok {
+		return "object"
+	}
+	if _, ok := toArray(value); ok {
+		return "array"
+	}
+	if _, ok := toRat(value); ok {
+		return "number"
+	}
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		// one whose exponent is out of range
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,121 @@
+package typed
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_Merge(t *testing.T) {
+	base, _ := JsonString(`{"server": {"host": "localhost", "port": 80}, "log": true, "tags": ["a"]}`)
+	env, _ := JsonString(`{"server": {"port": 8080, "tls": {"enabled": true}}, "log": false, "tags": ["b"]}`)
+	tenant, _ := JsonString(`{"server": {"host": "tenant.local"}, "name": "leto"}`)
+
+	merged, err := Merge(MergeOptions{}, base, env, tenant)
+	equal(t, err, nil)
+	equal(t, string(merged.MustBytes("")), `{"log":false,"name":"leto","server":{"host":"tenant.local","port":8080,"tls":{"enabled":true}},"tags":["b"]}`)
+
+	// inputs are untouched
+	equal(t, string(base.MustBytes("")), `{"log":true,"server":{"host":"localhost","port":80},"tags":["a"]}`)
+
+	merged.SetPath("server.tls.enabled", false)
+	equal(t, env.BoolPath("server.tls.enabled"), true)
+}
+
+func Test_MergeLeftWins(t *testing.T) {
+	left, _ := JsonString(`{"server": {"port": 80}, "tags": ["a"], "extra": null}`)
+	right, _ := JsonString(`{"server": {"port": 8080, "host": "localhost"}, "tags": ["b"], "extra": 1}`)
+	merged, err := Merge(MergeOptions{Scalars: LeftWins}, left, right)
+	equal(t, err, nil)
+	equal(t, string(merged.MustBytes("")), `{"extra":null,"server":{"host":"localhost","port":80},"tags":["a"]}`)
+}
+
+func Test_MergeArrays(t *testing.T) {
+	left, _ := JsonString(`{"tags": ["a", "b", 1]}`)
+	right, _ := JsonString(`{"tags": ["b", "c", 1.0]}`)
+
+	merged, _ := Merge(MergeOptions{Arrays: ArrayAppend}, left, right)
+	equal(t, string(merged.MustBytes("tags")), `["a","b",1,"b","c",1.0]`)
+
+	merged, _ = Merge(MergeOptions{Arrays: ArrayUnion}, left, right)
+	equal(t, string(merged.MustBytes("tags")), `["a","b",1,"c"]`)
+
+	merged, _ = Merge(MergeOptions{}, New(build("tags", []string{"x"})), New(build("tags", []int{1})))
+	equal(t, string(merged.MustBytes("tags")), `[1]`)
+}
+
+func Test_MergeUnionKey(t *testing.T) {
+	left, _ := JsonString(`{"users": [{"id": 1, "name": "leto"}, {"id": 2, "name": "paul"}]}`)
+	right := New(build("users", []Typed{build("id", 2, "age", 15), build("id", json.Number("3"), "name", "ghanima")}))
+	merged, err := Merge(MergeOptions{Arrays: ArrayUnion, UnionKey: "id"}, left, right)
+	equal(t, err, nil)
+	equal(t, string(merged.MustBytes("users")), `[{"id":1,"name":"leto"},{"age":15,"id":2,"name":"paul"},{"id":3,"name":"ghanima"}]`)
+}
+
+func Test_MergeConflicts(t *testing.T) {
+	left, _ := JsonString(`{"server": {"port": 80}, "users": [{"id": 1, "name": "leto"}]}`)
+	right, _ := JsonString(`{"server": "localhost:80"}`)
+
+	merged, err := Merge(MergeOptions{}, left, right)
+	equal(t, err, nil)
+	equal(t, merged.String("server"), "localhost:80")
+
+	merged, err = Merge(MergeOptions{Conflicts: ConflictError}, left, right)
+	equal(t, merged == nil, true)
+	equal(t, err.Error(), "cannot merge string into object at server")
+	equal(t, err.(*MergeError).Path, "server")
+
+	right, _ = JsonString(`{"users": [{"id": 1, "name": {"first": "leto"}}]}`)
+	_, err = Merge(MergeOptions{Conflicts: ConflictError, Arrays: ArrayUnion, UnionKey: "id"}, left, right)
+	equal(t, err.Error(), "cannot merge object into string at users[0].name")
+
+	scalars, _ := JsonString(`{"name": "x", "flag": true, "count": 1}`)
+	for _, document := range []string{`{"name": 1}`, `{"flag": "true"}`, `{"count": "1"}`} {
+		right, _ = JsonString(document)
+		_, err = Merge(MergeOptions{Conflicts: ConflictError}, scalars, right)
+		equal(t, err == nil, false)
+	}
+	equal(t, err.Error(), "cannot merge string into number at count")
+	right, _ = JsonString(`{"name": "y", "flag": false, "count": 1.5}`)
+	merged, err = Merge(MergeOptions{Conflicts: ConflictError}, scalars, right)
+	equal(t, err, nil)
+	equal(t, merged.Float("count"), 1.5)
+
+	right, _ = JsonString(`{"server": null}`)
+	merged, err = Merge(MergeOptions{Conflicts: ConflictError}, left, right)
+	equal(t, err, nil)
+	equal(t, merged.Exists("server"), true)
+	equal(t, merged.Interface("server"), nil)
+}
+
+func Test_Clone(t *testing.T) {
+	original := New(build("server", build("port", 80), "ints", []int{1}, "typed", []Typed{build("id", 1)}, "maps", []map[string]interface{}{build("id", 2)}, "list", []interface{}{build("id", 3)}))
+	clone := original.Clone()
+	clone.SetPath("server.port", 81)
+	clone.Ints("ints")[0] = 2
+	clone.SetPath("typed[0].id", 2)
+	clone.SetPath("maps[0].id", 3)
+	clone.SetPath("list[0].id", 4)
+	equal(t, string(original.MustBytes("")), `{"ints":[1],"list":[{"id":3}],"maps":[{"id":2}],"server":{"port":80},"typed":[{"id":1}]}`)
+	equal(t, string(clone.MustBytes("")), `{"ints":[2],"list":[{"id":4}],"maps":[{"id":3}],"server":{"port":81},"typed":[{"id":2}]}`)
+	equal(t, Typed(nil).Clone() == nil, true)
+}
+
+func Test_EqualValues(t *testing.T) {
+	equal(t, equalValues(json.Number("1"), 1.0), true)
+	equal(t, equalValues(int64(1), json.Number("1.0")), true)
+	equal(t, equalValues(uint8(2), 2), true)
+	equal(t, equalValues(json.Number("1"), "1"), false)
+	equal(t, equalValues("1", json.Number("1")), false)
+	equal(t, equalValues(nil, nil), true)
+	equal(t, equalValues(nil, false), false)
+	equal(t, equalValues(Typed(build("a", 1)), build("a", json.Number("1"))), true)
+	equal(t, equalValues(build("a", 1), build("a", 1, "b", 2)), false)
+	equal(t, equalValues(build("a", 1), build("b", 1)), false)
+	equal(t, equalValues([]int{1, 2}, []interface{}{1.0, json.Number("2")}), true)
+	equal(t, equalValues([]int{1, 2}, []interface{}{1.0}), false)
+	equal(t, equalValues("a", []interface{}{"a"}), false)
+	equal(t, equalValues("a", build("a", 1)), false)
+	equal(t, equalValues(json.Number("1e1000000000"), json.Number("1e1000000000")), true)
+	equal(t, equalValues(json.Number("1e1000000000"), json.Number("2e1000000000")), false)
+	equal(t, jsonType(json.Number("1e1000000000")), "number")
+}
@@ -0,0 +1,70 @@
+package typed
+
+// Applies an RFC 7396 JSON Merge Patch and returns the patched
+// document as a new Typed (t isn't modified). A null in the patch
+// removes the key, objects are merged recursively and any other
+// value (including arrays) replaces the existing one.
+func (t Typed) MergePatch(patch Typed) Typed {
+	return Typed(applyMergePatch(t, patch).(map[string]interface{}))
+}
+
+func applyMergePatch(target interface{}, patch interface{}) interface{} {
+	p, ok := toObject(patch)
+	if ok == false {
+		return plain(patch)
+	}
+
+	var result map[string]interface{}
+	if o, ok := toObject(target); ok {
+		result = plain(o).(map[string]interface{})
+	} else {
+		result = make(map[string]interface{}, len(p))
+	}
+
+	for key, value := range p {
+		if value == nil {
+			delete(result, key)
+		} else {
+			result[key] = applyMergePatch(result[key], value)
+		}
+	}
+	return result
+}
+
+// Returns the RFC 7396 JSON Merge Patch which transforms from into to.
+// Numbers are compared by value (see Merge). Since null is used to
+// remove a key, a null value in to can't be represented and is
+// treated as a removal.
+func CreateMergePatch(from Typed, to Typed) Typed {
+	return Typed(createMergePatch(from, to))
+}
+
+func createMergePatch(from map[string]interface{}, to map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for key, existing := range from {
+		if value, exists := to[key]; exists == false || (value == nil && existing != nil) {
+			patch[key] = nil
+		}
+	}
+
+	for key, value := range to {
+		if value == nil {
+			continue
+		}
+		existing, exists := from[key]
+		if exists == false || existing == nil {
+			patch[key] = plain(value)
+			continue
+		}
+		eo, existingObject := toObject(existing)
+		vo, valueObject := toObject(value)
+		if existingObject && valueObject {
+			if nested := createMergePatch(eo, vo); len(nested) > 0 {
+				patch[key] = nested
+			}
+		} else if equalValues(existing, value) == false {
+			patch[key] = plain(value)
+		}
+	}
+	return patch
+}
@@ -0,0 +1,52 @@
+package typed
+
+import (
+	"testing"
+)
+
+func Test_MergePatch(t *testing.T) {
+	// the example from RFC 7396
+	target, _ := JsonString(`{"title": "Goodbye!", "author": {"givenName": "John", "familyName": "Doe"}, "tags": ["example", "sample"], "content": "This will be unchanged"}`)
+	patch, _ := JsonString(`{"title": "Hello!", "phoneNumber": "+01-123-456-7890", "author": {"familyName": null}, "tags": ["example"]}`)
+	patched := target.MergePatch(patch)
+	equal(t, string(patched.MustBytes("")), `{"author":{"givenName":"John"},"content":"This will be unchanged","phoneNumber":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`)
+	equal(t, target.String("title"), "Goodbye!")
+	equal(t, target.ObjectPath("author").Exists("familyName"), true)
+}
+
+func Test_MergePatchRFCCases(t *testing.T) {
+	cases := [][3]string{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, c := range cases {
+		target, _ := JsonString(c[0])
+		patch, _ := JsonString(c[1])
+		equal(t, string(target.MergePatch(patch).MustBytes("")), c[2])
+	}
+}
+
+func Test_CreateMergePatch(t *testing.T) {
+	from, _ := JsonString(`{"title": "Goodbye!", "author": {"givenName": "John", "familyName": "Doe"}, "tags": ["example", "sample"], "content": "This will be unchanged", "count": 1, "old": null}`)
+	to, _ := JsonString(`{"title": "Hello!", "author": {"givenName": "John"}, "tags": ["example"], "content": "This will be unchanged", "count": 1.0, "phoneNumber": "+01-123-456-7890", "gone": null}`)
+	patch := CreateMergePatch(from, to)
+	equal(t, string(patch.MustBytes("")), `{"author":{"familyName":null},"old":null,"phoneNumber":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`)
+
+	patched := from.MergePatch(patch)
+	equal(t, equalValues(patched, to.MergePatch(New(build("gone", nil)))), true)
+
+	equal(t, len(CreateMergePatch(from, from)), 0)
+
+	from, _ = JsonString(`{"a": {"b": 1}, "c": null}`)
+	to, _ = JsonString(`{"a": 1, "c": {"d": 2}}`)
+	equal(t, string(CreateMergePatch(from, to).MustBytes("")), `{"a":1,"c":{"d":2}}`)
+	equal(t, string(CreateMergePatch(to, from).MustBytes("")), `{"a":{"b":1},"c":null}`)
+}
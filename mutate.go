@@ -0,0 +1,289 @@
+package typed
+
+import (
+	"reflect"
+)
+
+// Sets the value at the key
+func (t Typed) Set(key string, value interface{}) {
+	t[key] = value
+}
+
+// Removes the key. Returns whether or not the key existed
+func (t Typed) Delete(key string) bool {
+	_, exists := t[key]
+	delete(t, key)
+	return exists
+}
+
+// Sets the value at the dotted path. Missing intermediate values
+// are created: an array when the segment is a bracketed index
+// (e.g. "items[0].sku"), an object otherwise. An index equal to the
+// length of an array appends to it.
+// InvalidPath is returned if the path is malformed or contains a
+// wildcard. PathConflict is returned if the path traverses a value
+// which isn't an object or an array, or an index past the end of an
+// array (a nil object is created like a missing one), or if t itself
+// is nil. In both cases, t is left as-is.
+func (t Typed) SetPath(path string, value interface{}) error {
+	segments, ok := parsePath(path)
+	if ok == false || hasWildcard(segments) {
+		return InvalidPath
+	}
+	if t == nil {
+		return PathConflict
+	}
+	_, err := assign(t, segments, value)
+	return err
+}
+
+// Removes the value at the dotted path. Removing an element
+// from an array shifts the elements which follow it.
+// Returns whether or not the path existed
+func (t Typed) DeletePath(path string) bool {
+	segments, ok := parsePath(path)
+	if ok == false || hasWildcard(segments) {
+		return false
+	}
+	_, removed := remove(t, segments)
+	return removed
+}
+
+// Sets the value within the container, returning the container
+// (which is a new slice when an array had to be extended)
+func assign(container interface{}, segments []segment, value interface{}) (interface{}, error) {
+	s, rest := segments[0], segments[1:]
+	// a nil map is treated like a missing value, it can't be written to
+	switch c := container.(type) {
+	case Typed:
+		if c == nil {
+			container = nil
+		}
+	case map[string]interface{}:
+		if c == nil {
+			container = nil
+		}
+	}
+	if container == nil {
+		if s.index {
+			container = []interface{}{}
+		} else {
+			container = make(map[string]interface{})
+		}
+	}
+
+	switch c := container.(type) {
+	case Typed:
+		return c, assignKey(c, s.key, rest, value)
+	case map[string]interface{}:
+		return c, assignKey(c, s.key, rest, value)
+	case []interface{}:
+		// an existing element, or the one past the end
+		i, ok := index(s.key, len(c)+1)
+		if ok == false {
+			return nil, PathConflict
+		}
+		if i == len(c) {
+			c = append(c, nil)
+		}
+		if len(rest) == 0 {
+			c[i] = value
+			return c, nil
+		}
+		next, err := assign(c[i], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = next
+		return c, nil
+	case []Typed:
+		i, ok := index(s.key, len(c))
+		if ok == false {
+			return nil, PathConflict
+		}
+		if len(rest) > 0 {
+			next, err := assign(c[i], rest, value)
+			if err != nil {
+				return nil, err
+			}
+			// a nil element is replaced by a new object, not an array
+			o, ok := toObject(next)
+			if ok == false {
+				return nil, PathConflict
+			}
+			c[i] = o
+			return c, nil
+		}
+		o, ok := toObject(value)
+		if ok == false {
+			return nil, PathConflict
+		}
+		c[i] = o
+		return c, nil
+	case []map[string]interface{}:
+		i, ok := index(s.key, len(c))
+		if ok == false {
+			return nil, PathConflict
+		}
+		if len(rest) > 0 {
+			next, err := assign(c[i], rest, value)
+			if err != nil {
+				return nil, err
+			}
+			// a nil element is replaced by a new object, not an array
+			o, ok := toObject(next)
+			if ok == false {
+				return nil, PathConflict
+			}
+			c[i] = o
+			return c, nil
+		}
+		o, ok := toObject(value)
+		if ok == false {
+			return nil, PathConflict
+		}
+		c[i] = o
+		return c, nil
+	}
+	return nil, PathConflict
+}
+
+func assignKey(m map[string]interface{}, key string, rest []segment, value interface{}) error {
+	if len(rest) == 0 {
+		m[key] = value
+		return nil
+	}
+	next, err := assign(m[key], rest, value)
+	if err != nil {
+		return err
+	}
+	m[key] = next
+	return nil
+}
+
+// Removes the value from within the container, returning the
+// container (which is a new slice when an element was removed
+// from an array) and whether or not the value existed
+func remove(container interface{}, segments []segment) (interface{}, bool) {
+	s, rest := segments[0], segments[1:]
+	switch c := container.(type) {
+	case Typed:
+		return c, removeKey(c, s.key, rest)
+	case map[string]interface{}:
+		return c, removeKey(c, s.key, rest)
+	case []interface{}:
+		i, ok := index(s.key, len(c))
+		if ok == false {
+			return c, false
+		}
+		if len(rest) == 0 {
+			n := make([]interface{}, 0, len(c)-1)
+			return append(append(n, c[:i]...), c[i+1:]...), true
+		}
+		next, removed := remove(c[i], rest)
+		c[i] = next
+		return c, removed
+	case []Typed:
+		i, ok := index(s.key, len(c))
+		if ok == false {
+			return c, false
+		}
+		if len(rest) == 0 {
+			n := make([]Typed, 0, len(c)-1)
+			return append(append(n, c[:i]...), c[i+1:]...), true
+		}
+		_, removed := remove(c[i], rest)
+		return c, removed
+	case []map[string]interface{}:
+		i, ok := index(s.key, len(c))
+		if ok == false {
+			return c, false
+		}
+		if len(rest) == 0 {
+			n := make([]map[string]interface{}, 0, len(c)-1)
+			return append(append(n, c[:i]...), c[i+1:]...), true
+		}
+		_, removed := remove(c[i], rest)
+		return c, removed
+	}
+	return container, false
+}
+
+func removeKey(m map[string]interface{}, key string, rest []segment) bool {
+	value, exists := m[key]
+	if exists == false {
+		return false
+	}
+	if len(rest) == 0 {
+		delete(m, key)
+		return true
+	}
+	next, removed := remove(value, rest)
+	m[key] = next
+	return removed
+}
+
+// Returns a deep copy of t. Nested objects and arrays are copied,
+// keeping their type (e.g. a []Typed remains a []Typed)
+func (t Typed) Clone() Typed {
+	if t == nil {
+		return nil
+	}
+	return cloneMap(t)
+}
+
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = cloneValue(v)
+	}
+	return c
+}
+
+func cloneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Typed:
+		return v.Clone()
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		return cloneMap(v)
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		c := make([]interface{}, len(v))
+		for i, e := range v {
+			c[i] = cloneValue(e)
+		}
+		return c
+	case []Typed:
+		if v == nil {
+			return v
+		}
+		c := make([]Typed, len(v))
+		for i, e := range v {
+			c[i] = e.Clone()
+		}
+		return c
+	case []map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		c := make([]map[string]interface{}, len(v))
+		for i, e := range v {
+			c[i] = cloneMap(e)
+		}
+		return c
+	}
+
+	// slices of scalars ([]int, []string, ...)
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice && rv.IsNil() == false {
+		c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(c, rv)
+		return c.Interface()
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+package typed
+
+import (
+	"testing"
+)
+
+func Test_Set(t *testing.T) {
+	typed := New(build("name", "leto"))
+	typed.Set("name", "paul")
+	typed.Set("power", 9001)
+	equal(t, typed.String("name"), "paul")
+	equal(t, typed.Int("power"), 9001)
+}
+
+func Test_Delete(t *testing.T) {
+	typed := New(build("name", "leto"))
+	equal(t, typed.Delete("name"), true)
+	equal(t, typed.Delete("name"), false)
+	equal(t, typed.Exists("name"), false)
+}
+
+func Test_SetPath(t *testing.T) {
+	typed := New(build("server", build("host", "localhost")))
+	equal(t, typed.SetPath("server.port", 80), nil)
+	equal(t, typed.SetPath("server.tls.port", 443), nil)
+	equal(t, typed.IntPath("server.port"), 80)
+	equal(t, typed.IntPath("server.tls.port"), 443)
+	equal(t, typed.StringPath("server.host"), "localhost")
+
+	m, err := typed.ToBytes("")
+	equal(t, err, nil)
+	equal(t, string(m), `{"server":{"host":"localhost","port":80,"tls":{"port":443}}}`)
+}
+
+func Test_SetPathArrays(t *testing.T) {
+	typed := New(build())
+	equal(t, typed.SetPath("items[0]", "a0"), nil)
+	equal(t, typed.SetPath("items[1].sku", "a1"), nil)
+	equal(t, typed.SetPath("matrix[0][0]", 4), nil)
+	equal(t, string(typed.MustBytes("")), `{"items":["a0",{"sku":"a1"}],"matrix":[[4]]}`)
+
+	equal(t, typed.SetPath("items.2", "a2"), nil)
+	equal(t, typed.SetPath("items.1.price", 1.5), nil)
+	equal(t, typed.SetPath("items[0]", "b0"), nil)
+	equal(t, string(typed.MustBytes("items")), `["b0",{"price":1.5,"sku":"a1"},"a2"]`)
+
+	typed = New(build("typed", []Typed{build("id", 1)}, "maps", []map[string]interface{}{build("id", 2)}))
+	equal(t, typed.SetPath("typed[0].id", 3), nil)
+	equal(t, typed.SetPath("maps[0]", build("id", 4)), nil)
+	equal(t, typed.IntPath("typed[0].id"), 3)
+	equal(t, typed.IntPath("maps[0].id"), 4)
+	equal(t, typed.SetPath("typed[1].id", 3), PathConflict)
+	equal(t, typed.SetPath("maps[0]", 4), PathConflict)
+}
+
+func Test_SetPathErrors(t *testing.T) {
+	typed := New(build("name", "leto", "items", []interface{}{1}))
+	equal(t, typed.SetPath("items[*].sku", 1), InvalidPath)
+	equal(t, typed.SetPath("items[0", 1), InvalidPath)
+	equal(t, typed.SetPath("name.first", "leto"), PathConflict)
+	equal(t, typed.SetPath("items.first", 1), PathConflict)
+	equal(t, typed.SetPath("items[0].sku", 1), PathConflict)
+	equal(t, typed.SetPath("items[2]", 1), PathConflict)
+	equal(t, typed.SetPath("items[2000000000]", 1), PathConflict)
+	equal(t, typed.SetPath("other[1].sku", 1), PathConflict)
+	equal(t, string(typed.MustBytes("")), `{"items":[1],"name":"leto"}`)
+}
+
+func Test_DeletePath(t *testing.T) {
+	typed, _ := JsonString(`{"server": {"host": "localhost", "tls": {"port": 443}}, "items": [{"sku": "a"}, {"sku": "b"}, 3]}`)
+	equal(t, typed.DeletePath("server.tls.port"), true)
+	equal(t, typed.DeletePath("server.tls.port"), false)
+	equal(t, typed.DeletePath("items[0].sku"), true)
+	equal(t, typed.DeletePath("items[1]"), true)
+	equal(t, typed.DeletePath("items[2]"), false)
+	equal(t, typed.DeletePath("items[*]"), false)
+	equal(t, typed.DeletePath("server.host.x"), false)
+	equal(t, string(typed.MustBytes("")), `{"items":[{},3],"server":{"host":"localhost","tls":{}}}`)
+
+	typed = New(build("typed", []Typed{build("id", 1), build("id", 2)}, "maps", []map[string]interface{}{build("id", 3)}))
+	equal(t, typed.DeletePath("typed[0]"), true)
+	equal(t, typed.DeletePath("maps[0].id"), true)
+	equal(t, string(typed.MustBytes("")), `{"maps":[{}],"typed":[{"id":2}]}`)
+}
+
+func Test_SetPathNilObjects(t *testing.T) {
+	var nilMap map[string]interface{}
+	typed := New(build("a", Empty, "b", nilMap, "c", Typed(nil), "list", []Typed{nil, nil}))
+	equal(t, typed.SetPath("a.x", 1), nil)
+	equal(t, typed.SetPath("b.x", 2), nil)
+	equal(t, typed.SetPath("c[0]", 3), nil)
+	equal(t, typed.SetPath("list[0].x", 4), nil)
+	equal(t, typed.IntPath("a.x"), 1)
+	equal(t, typed.IntPath("b.x"), 2)
+	equal(t, typed.IntPath("c[0]"), 3)
+	equal(t, typed.IntPath("list[0].x"), 4)
+	equal(t, typed.SetPath("list[1][0]", 5), PathConflict)
+	equal(t, typed["list"].([]Typed)[1] == nil, true)
+
+	equal(t, Typed(nil).SetPath("a", 1), PathConflict)
+}
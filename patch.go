@@ -0,0 +1,208 @@
+package typed
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Returned by Patch when an operation can't be applied
+type PatchError struct {
+	// The index of the operation within the patch
+	Index int
+	Op    string
+	Path  string
+	// Why the operation failed
+	Reason string
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("patch operation %d (%s %s): %s", e.Index, e.Op, e.Path, e.Reason)
+}
+
+// Applies an RFC 6902 JSON Patch (as parsed by JsonArray) and returns
+// the patched document as a new Typed. The patch is atomic: if any
+// operation fails (including a failed "test"), a *PatchError is
+// returned and t is left as-is.
+func (t Typed) Patch(operations []Typed) (Typed, error) {
+	var doc interface{} = plain(t)
+	for i, operation := range operations {
+		op := operation.String("op")
+		path, ok := operation.StringIf("path")
+		if ok == false {
+			return nil, &PatchError{Index: i, Op: op, Reason: "missing path"}
+		}
+
+		var reason string
+		doc, reason = applyOperation(doc, op, path, operation)
+		if reason != "" {
+			return nil, &PatchError{Index: i, Op: op, Path: path, Reason: reason}
+		}
+	}
+	return Typed(doc.(map[string]interface{})), nil
+}
+
+func applyOperation(doc interface{}, op string, path string, operation Typed) (interface{}, string) {
+	segments, ok := parsePointer(path)
+	if ok == false {
+		return nil, "invalid path"
+	}
+
+	switch op {
+	case "add", "replace", "test":
+		value, exists := operation.InterfaceIf("value")
+		if exists == false {
+			return nil, "missing value"
+		}
+		if op == "test" {
+			if actual, exists := walk(doc, segments); exists == false {
+				return nil, "path not found"
+			} else if equalValues(actual, value) == false {
+				return nil, "test failed"
+			}
+			return doc, ""
+		}
+		if op == "add" {
+			return addValue(doc, segments, plain(value))
+		}
+		return replaceValue(doc, segments, plain(value))
+	case "remove":
+		return removeValue(doc, segments)
+	case "move", "copy":
+		from, exists := operation.StringIf("from")
+		if exists == false {
+			return nil, "missing from"
+		}
+		fromSegments, ok := parsePointer(from)
+		if ok == false {
+			return nil, "invalid from"
+		}
+		value, exists := walk(doc, fromSegments)
+		if exists == false {
+			return nil, "from not found"
+		}
+		if op == "copy" {
+			return addValue(doc, segments, plain(value))
+		}
+		if from == path {
+			return doc, ""
+		}
+		if strings.HasPrefix(path, from+"/") {
+			return nil, "cannot move a value into one of its children"
+		}
+		doc, reason := removeValue(doc, fromSegments)
+		if reason != "" {
+			return nil, reason
+		}
+		return addValue(doc, segments, value)
+	}
+	return nil, "unknown op"
+}
+
+// Adds the value, inserting it when the parent is an array.
+// "-" appends to an array.
+func addValue(doc interface{}, segments []segment, value interface{}) (interface{}, string) {
+	if len(segments) == 0 {
+		return replaceRoot(value)
+	}
+	return updateParent(doc, segments, func(parent interface{}, key string) (interface{}, string) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			p[key] = value
+			return p, ""
+		case []interface{}:
+			if key == "-" {
+				return append(p, value), ""
+			}
+			i, ok := index(key, len(p)+1)
+			if ok == false {
+				return nil, "index out of bounds"
+			}
+			n := make([]interface{}, 0, len(p)+1)
+			n = append(append(append(n, p[:i]...), value), p[i:]...)
+			return n, ""
+		}
+		return nil, "path not found"
+	})
+}
+
+func replaceValue(doc interface{}, segments []segment, value interface{}) (interface{}, string) {
+	if len(segments) == 0 {
+		return replaceRoot(value)
+	}
+	return updateParent(doc, segments, func(parent interface{}, key string) (interface{}, string) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			if _, exists := p[key]; exists {
+				p[key] = value
+				return p, ""
+			}
+		case []interface{}:
+			if i, ok := index(key, len(p)); ok {
+				p[i] = value
+				return p, ""
+			}
+		}
+		return nil, "path not found"
+	})
+}
+
+func removeValue(doc interface{}, segments []segment) (interface{}, string) {
+	if len(segments) == 0 {
+		return nil, "cannot remove the root"
+	}
+	return updateParent(doc, segments, func(parent interface{}, key string) (interface{}, string) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			if _, exists := p[key]; exists {
+				delete(p, key)
+				return p, ""
+			}
+		case []interface{}:
+			if i, ok := index(key, len(p)); ok {
+				n := make([]interface{}, 0, len(p)-1)
+				return append(append(n, p[:i]...), p[i+1:]...), ""
+			}
+		}
+		return nil, "path not found"
+	})
+}
+
+func replaceRoot(value interface{}) (interface{}, string) {
+	if _, ok := value.(map[string]interface{}); ok == false {
+		return nil, "the root must be an object"
+	}
+	return value, ""
+}
+
+// Walks to the parent of the last segment and calls apply with it.
+// The (possibly new) parent returned by apply replaces the existing
+// one. The document, as produced by plain, only contains
+// map[string]interface{} and []interface{} containers.
+func updateParent(container interface{}, segments []segment, apply func(parent interface{}, key string) (interface{}, string)) (interface{}, string) {
+	key := segments[0].key
+	if len(segments) == 1 {
+		return apply(container, key)
+	}
+
+	switch c := container.(type) {
+	case map[string]interface{}:
+		if child, exists := c[key]; exists {
+			next, reason := updateParent(child, segments[1:], apply)
+			if reason != "" {
+				return nil, reason
+			}
+			c[key] = next
+			return c, ""
+		}
+	case []interface{}:
+		if i, ok := index(key, len(c)); ok {
+			next, reason := updateParent(c[i], segments[1:], apply)
+			if reason != "" {
+				return nil, reason
+			}
+			c[i] = next
+			return c, ""
+		}
+	}
+	return nil, "path not found"
+}
@@ -0,0 +1,75 @@
+package typed
+
+import (
+	"testing"
+)
+
+func Test_Patch(t *testing.T) {
+	// examples from RFC 6902 appendix A
+	cases := [][3]string{
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`, `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`, `{"child":{"grandchild":{}},"foo":"bar"}`},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux", "xyz": 123}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"/": 9, "~1": 10}`, `[{"op": "test", "path": "/~01", "value": 10}]`, `{"/":9,"~1":10}`},
+		{`{"foo": null}`, `[{"op": "test", "path": "/foo", "value": null}]`, `{"foo":null}`},
+		{`{"a": {"b": 1}}`, `[{"op": "copy", "from": "/a", "path": "/c"}, {"op": "replace", "path": "/c/b", "value": 2}]`, `{"a":{"b":1},"c":{"b":2}}`},
+		{`{"a": 1}`, `[{"op": "replace", "path": "", "value": {"b": 2}}]`, `{"b":2}`},
+		{`{"a": 1}`, `[{"op": "move", "from": "/a", "path": "/a"}]`, `{"a":1}`},
+	}
+	for _, c := range cases {
+		doc, _ := JsonString(c[0])
+		patch, _ := JsonStringArray(c[1])
+		patched, err := doc.Patch(patch)
+		equal(t, err, nil)
+		equal(t, string(patched.MustBytes("")), c[2])
+	}
+}
+
+func Test_PatchErrors(t *testing.T) {
+	cases := [][2]string{
+		{`[{"op": "test", "path": "/baz", "value": "bar"}]`, "patch operation 0 (test /baz): test failed"},
+		{`[{"op": "add", "path": "/baz/bat", "value": "qux"}]`, "patch operation 0 (add /baz/bat): path not found"},
+		{`[{"op": "add", "path": "/foo/4", "value": "qux"}]`, "patch operation 0 (add /foo/4): index out of bounds"},
+		{`[{"op": "remove", "path": "/nope"}]`, "patch operation 0 (remove /nope): path not found"},
+		{`[{"op": "remove", "path": ""}]`, "patch operation 0 (remove ): cannot remove the root"},
+		{`[{"op": "replace", "path": "/foo/3", "value": 1}]`, "patch operation 0 (replace /foo/3): path not found"},
+		{`[{"op": "replace", "path": "", "value": 1}]`, "patch operation 0 (replace ): the root must be an object"},
+		{`[{"op": "add", "path": "/baz"}]`, "patch operation 0 (add /baz): missing value"},
+		{`[{"op": "add", "value": 1}]`, "patch operation 0 (add ): missing path"},
+		{`[{"op": "add", "path": "baz", "value": 1}]`, "patch operation 0 (add baz): invalid path"},
+		{`[{"op": "move", "path": "/baz"}]`, "patch operation 0 (move /baz): missing from"},
+		{`[{"op": "copy", "from": "/nope", "path": "/baz"}]`, "patch operation 0 (copy /baz): from not found"},
+		{`[{"op": "copy", "from": "nope", "path": "/baz"}]`, "patch operation 0 (copy /baz): invalid from"},
+		{`[{"op": "move", "from": "/foo", "path": "/foo/0"}]`, "patch operation 0 (move /foo/0): cannot move a value into one of its children"},
+		{`[{"op": "nope", "path": "/foo"}]`, "patch operation 0 (nope /foo): unknown op"},
+		{`[{"op": "add", "path": "/a", "value": 1}, {"op": "test", "path": "/a", "value": 2}]`, "patch operation 1 (test /a): test failed"},
+	}
+	for _, c := range cases {
+		doc, _ := JsonString(`{"baz": "qux", "foo": ["bar"]}`)
+		patch, _ := JsonStringArray(c[0])
+		patched, err := doc.Patch(patch)
+		equal(t, patched == nil, true)
+		equal(t, err.Error(), c[1])
+		equal(t, string(doc.MustBytes("")), `{"baz":"qux","foo":["bar"]}`)
+	}
+}
+
+func Test_PatchErrorFields(t *testing.T) {
+	doc, _ := JsonString(`{"a": 1}`)
+	patch, _ := JsonStringArray(`[{"op": "replace", "path": "/a", "value": 2}, {"op": "remove", "path": "/b"}]`)
+	_, err := doc.Patch(patch)
+	pe := err.(*PatchError)
+	equal(t, pe.Index, 1)
+	equal(t, pe.Op, "remove")
+	equal(t, pe.Path, "/b")
+	equal(t, pe.Reason, "path not found")
+	equal(t, doc.Int("a"), 1)
+}
@@ -0,0 +1,553 @@
+package typed
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A parsed segment of a path. A wildcard segment fans out
+// over every element of an array. An index segment was given
+// in brackets (e.g. "items[3]") and, when set, creates an array
+type segment struct {
+	key      string
+	index    bool
+	wildcard bool
+}
+
+// Returns the value at the dotted path (e.g. "server.tls.port")
+// and whether or not every segment of the path existed.
+// Objects are walked by key. Arrays are walked by a numeric
+// segment (e.g. "servers.0.port" or "servers[0].port").
+// A wildcard (e.g. "items[*].price") collects the value from
+// every element of the array into a []interface{}. Elements
+// which don't have the remainder of the path are skipped.
+func (t Typed) lookup(path string) (interface{}, bool) {
+	segments, ok := parsePath(path)
+	if ok == false {
+		return nil, false
+	}
+	return walk(t, segments)
+}
+
+func walk(value interface{}, segments []segment) (interface{}, bool) {
+	for i, s := range segments {
+		if s.wildcard {
+			return fanout(value, segments[i+1:])
+		}
+		var exists bool
+		if value, exists = child(value, s.key); exists == false {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func fanout(container interface{}, segments []segment) (interface{}, bool) {
+	elements, ok := toArray(container)
+	if ok == false {
+		return nil, false
+	}
+
+	nested := hasWildcard(segments)
+	values := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		value, exists := walk(element, segments)
+		if exists == false {
+			continue
+		}
+		if nested {
+			values = append(values, value.([]interface{})...)
+		} else {
+			values = append(values, value)
+		}
+	}
+	return values, true
+}
+
+func hasWildcard(segments []segment) bool {
+	for _, s := range segments {
+		if s.wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// Splits a path into its segments. Returns false if the
+// path is malformed (e.g. an unclosed bracket)
+func parsePath(path string) ([]segment, bool) {
+	segments := make([]segment, 0, 4)
+	for _, part := range strings.Split(path, ".") {
+		i := strings.IndexByte(part, '[')
+		if i == -1 {
+			segments = append(segments, segment{key: part})
+			continue
+		}
+		if i > 0 {
+			segments = append(segments, segment{key: part[:i]})
+		}
+		for part = part[i:]; len(part) > 0; {
+			end := strings.IndexByte(part, ']')
+			if part[0] != '[' || end == -1 {
+				return nil, false
+			}
+			key := part[1:end]
+			segments = append(segments, segment{key: key, index: true, wildcard: key == "*"})
+			part = part[end+1:]
+		}
+	}
+	return segments, true
+}
+
+// Returns the value within the container (an object or an array)
+// at the given key. The containers that are handled mirror those
+// of ObjectsIf
+func child(container interface{}, key string) (interface{}, bool) {
+	switch c := container.(type) {
+	case Typed:
+		value, exists := c[key]
+		return value, exists
+	case map[string]interface{}:
+		value, exists := c[key]
+		return value, exists
+	case []interface{}:
+		if i, ok := index(key, len(c)); ok {
+			return c[i], true
+		}
+	case []Typed:
+		if i, ok := index(key, len(c)); ok {
+			return c[i], true
+		}
+	case []map[string]interface{}:
+		if i, ok := index(key, len(c)); ok {
+			return c[i], true
+		}
+	}
+	return nil, false
+}
+
+// Returns the array index represented by key. Only plain
+// digits, without leading zeros, are accepted
+func index(key string, l int) (int, bool) {
+	if len(key) == 0 || (len(key) > 1 && key[0] == '0') {
+		return 0, false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < '0' || key[i] > '9' {
+			return 0, false
+		}
+	}
+	i, err := strconv.Atoi(key)
+	if err != nil || i >= l {
+		return 0, false
+	}
+	return i, true
+}
+
+// Returns true if every segment of the path exists
+func (t Typed) ExistsPath(path string) bool {
+	_, exists := t.lookup(path)
+	return exists
+}
+
+// Returns a boolean at the path, or false if it
+// doesn't exist, or if it isn't a bool
+func (t Typed) BoolPath(path string) bool {
+	return t.BoolPathOr(path, false)
+}
+
+// Returns a boolean at the path, or the specified
+// value if it doesn't exist or isn't a bool
+func (t Typed) BoolPathOr(path string, d bool) bool {
+	if value, exists := t.BoolPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a bool at the path or panics
+func (t Typed) BoolPathMust(path string) bool {
+	b, exists := t.BoolPathIf(path)
+	if exists == false {
+		panic(t.pathError(path, "boolean"))
+	}
+	return b
+}
+
+// Returns a boolean at the path and whether
+// or not the path existed and the value was a bolean
+func (t Typed) BoolPathIf(path string) (bool, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return false, false
+	}
+	return toBool(value)
+}
+
+func (t Typed) IntPath(path string) int {
+	return t.IntPathOr(path, 0)
+}
+
+// Returns a int at the path, or the specified
+// value if it doesn't exist or isn't a int
+func (t Typed) IntPathOr(path string, d int) int {
+	if value, exists := t.IntPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an int at the path or panics
+func (t Typed) IntPathMust(path string) int {
+	i, exists := t.IntPathIf(path)
+	if exists == false {
+		panic(t.pathError(path, "int"))
+	}
+	return i
+}
+
+// Returns an int at the path and whether
+// or not the path existed and the value was an int
+func (t Typed) IntPathIf(path string) (int, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return 0, false
+	}
+	return toInt(value)
+}
+
+func (t Typed) FloatPath(path string) float64 {
+	return t.FloatPathOr(path, 0)
+}
+
+// Returns a float at the path, or the specified
+// value if it doesn't exist or isn't a float
+func (t Typed) FloatPathOr(path string, d float64) float64 {
+	if value, exists := t.FloatPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a float at the path or panics
+func (t Typed) FloatPathMust(path string) float64 {
+	f, exists := t.FloatPathIf(path)
+	if exists == false {
+		panic(t.pathError(path, "float"))
+	}
+	return f
+}
+
+// Returns a float at the path and whether
+// or not the path existed and the value was a float
+func (t Typed) FloatPathIf(path string) (float64, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return 0, false
+	}
+	return toFloat(value)
+}
+
+func (t Typed) StringPath(path string) string {
+	return t.StringPathOr(path, "")
+}
+
+// Returns a string at the path, or the specified
+// value if it doesn't exist or isn't a string
+func (t Typed) StringPathOr(path string, d string) string {
+	if value, exists := t.StringPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a string at the path or panics
+func (t Typed) StringPathMust(path string) string {
+	s, exists := t.StringPathIf(path)
+	if exists == false {
+		panic(t.pathError(path, "string"))
+	}
+	return s
+}
+
+// Returns a string at the path and whether
+// or not the path existed and the value was a string
+func (t Typed) StringPathIf(path string) (string, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return "", false
+	}
+	return toString(value)
+}
+
+func (t Typed) TimePath(path string) time.Time {
+	return t.TimePathOr(path, time.Time{})
+}
+
+// Returns a time at the path, or the specified
+// value if it doesn't exist or isn't a time
+func (t Typed) TimePathOr(path string, d time.Time) time.Time {
+	if value, exists := t.TimePathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a time.Time at the path or panics
+func (t Typed) TimePathMust(path string) time.Time {
+	tt, exists := t.TimePathIf(path)
+	if exists == false {
+		panic(t.pathError(path, "time.Time"))
+	}
+	return tt
+}
+
+// Returns a time.Time at the path and whether
+// or not the path existed and the value was a time.Time
+func (t Typed) TimePathIf(path string) (time.Time, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return time.Time{}, false
+	}
+	return toTime(value)
+}
+
+// Returns a Typed helper at the path
+// If the path doesn't exist, a default Typed helper
+// is returned (which will return default values for
+// any subsequent sub queries)
+func (t Typed) ObjectPath(path string) Typed {
+	o := t.ObjectPathOr(path, nil)
+	if o == nil {
+		return Typed(nil)
+	}
+	return o
+}
+
+// Returns a Typed helper at the path or the specified
+// default if the path doesn't exist or if the value isn't
+// a map[string]interface{}
+func (t Typed) ObjectPathOr(path string, d map[string]interface{}) Typed {
+	if value, exists := t.ObjectPathIf(path); exists {
+		return value
+	}
+	return Typed(d)
+}
+
+// Returns a typed object at the path or panics
+func (t Typed) ObjectPathMust(path string) Typed {
+	o, exists := t.ObjectPathIf(path)
+	if exists == false {
+		panic(t.pathError(path, "map"))
+	}
+	return o
+}
+
+// Returns a Typed helper at the path and whether
+// or not the path existed and the value was an map[string]interface{}
+func (t Typed) ObjectPathIf(path string) (Typed, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return toObject(value)
+}
+
+func (t Typed) InterfacePath(path string) interface{} {
+	return t.InterfacePathOr(path, nil)
+}
+
+// Returns the value at the path, or the specified
+// value if it doesn't exist
+func (t Typed) InterfacePathOr(path string, d interface{}) interface{} {
+	if value, exists := t.InterfacePathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns the value at the path or panics
+func (t Typed) InterfacePathMust(path string) interface{} {
+	i, exists := t.InterfacePathIf(path)
+	if exists == false {
+		panic(t.pathError(path, ""))
+	}
+	return i
+}
+
+// Returns the value at the path and whether
+// or not the path existed
+func (t Typed) InterfacePathIf(path string) (interface{}, bool) {
+	return t.lookup(path)
+}
+
+// Returns a map[string]interface{} at the path
+// or a nil map if the path doesn't exist or if the value isn't
+// a map[string]interface
+func (t Typed) MapPath(path string) map[string]interface{} {
+	return t.MapPathOr(path, nil)
+}
+
+// Returns a map[string]interface{} at the path
+// or the specified default if the path doesn't exist
+// or if the value isn't a map[string]interface
+func (t Typed) MapPathOr(path string, d map[string]interface{}) map[string]interface{} {
+	if value, exists := t.MapPathIf(path); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a map[string]interface at the path and whether
+// or not the path existed and the value was an map[string]interface{}
+func (t Typed) MapPathIf(path string) (map[string]interface{}, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return toMap(value)
+}
+
+// Returns an slice of boolean at the path, or an nil slice
+func (t Typed) BoolsPath(path string) []bool {
+	return t.BoolsPathOr(path, nil)
+}
+
+// Returns an slice of boolean at the path, or the specified slice
+func (t Typed) BoolsPathOr(path string, d []bool) []bool {
+	n, ok := t.BoolsPathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a boolean slice at the path + true if valid
+// Returns nil + false otherwise
+func (t Typed) BoolsPathIf(path string) ([]bool, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return toBools(value)
+}
+
+// Returns an slice of ints at the path, or a nil slice
+func (t Typed) IntsPath(path string) []int {
+	return t.IntsPathOr(path, nil)
+}
+
+// Returns an slice of ints at the path, or the specified slice
+func (t Typed) IntsPathOr(path string, d []int) []int {
+	n, ok := t.IntsPathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a int slice at the path + true if valid
+// Returns nil + false otherwise
+func (t Typed) IntsPathIf(path string) ([]int, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return toInts(value)
+}
+
+// Returns an slice of ints64 at the path, or a nil slice
+func (t Typed) Ints64Path(path string) []int64 {
+	return t.Ints64PathOr(path, nil)
+}
+
+// Returns an slice of ints64 at the path, or the specified slice
+func (t Typed) Ints64PathOr(path string, d []int64) []int64 {
+	n, ok := t.Ints64PathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a int64 slice at the path + true if valid
+// Returns nil + false otherwise
+func (t Typed) Ints64PathIf(path string) ([]int64, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return toInts64(value)
+}
+
+// Returns an slice of floats at the path, or a nil slice
+func (t Typed) FloatsPath(path string) []float64 {
+	return t.FloatsPathOr(path, nil)
+}
+
+// Returns an slice of floats at the path, or the specified slice
+func (t Typed) FloatsPathOr(path string, d []float64) []float64 {
+	n, ok := t.FloatsPathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a float slice at the path + true if valid
+// Returns nil + false otherwise
+func (t Typed) FloatsPathIf(path string) ([]float64, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return toFloats(value)
+}
+
+// Returns an slice of strings at the path, or a nil slice
+func (t Typed) StringsPath(path string) []string {
+	return t.StringsPathOr(path, nil)
+}
+
+// Returns an slice of strings at the path, or the specified slice
+func (t Typed) StringsPathOr(path string, d []string) []string {
+	n, ok := t.StringsPathIf(path)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a string slice at the path + true if valid
+// Returns nil + false otherwise
+func (t Typed) StringsPathIf(path string) ([]string, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return toStrings(value)
+}
+
+// Returns an slice of Typed helpers at the path, or a nil slice
+func (t Typed) ObjectsPath(path string) []Typed {
+	value, _ := t.ObjectsPathIf(path)
+	return value
+}
+
+// Returns a slice of Typed helpers at the path and true if exists, otherwise; nil and false.
+func (t Typed) ObjectsPathIf(path string) ([]Typed, bool) {
+	value, exists := t.lookup(path)
+	if exists == false {
+		return nil, false
+	}
+	return toObjects(value)
+}
+
+func (t Typed) ObjectsPathMust(path string) []Typed {
+	value, exists := t.ObjectsPathIf(path)
+	if exists == false {
+		panic(t.pathError(path, "objects"))
+	}
+	return value
+}
@@ -0,0 +1,216 @@
+package typed
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func Test_Lookup(t *testing.T) {
+	typed := New(build("server", build("tls", Typed(build("port", 443))), "servers", []interface{}{build("port", 80), build("port", 81)}))
+	value, exists := typed.lookup("server.tls.port")
+	equal(t, value, 443)
+	equal(t, exists, true)
+
+	value, exists = typed.lookup("servers.1.port")
+	equal(t, value, 81)
+	equal(t, exists, true)
+
+	for _, path := range []string{"other", "server.other", "server.tls.port.other", "servers.2.port", "servers.-1.port", "servers.a.port", ""} {
+		value, exists = typed.lookup(path)
+		equal(t, value, nil)
+		equal(t, exists, false)
+	}
+
+	equal(t, typed.ExistsPath("server.tls"), true)
+	equal(t, typed.ExistsPath("server.ssl"), false)
+}
+
+func Test_LookupArrayTypes(t *testing.T) {
+	typed := New(build("typed", []Typed{build("id", 1)}, "maps", []map[string]interface{}{build("id", 2)}))
+	equal(t, typed.IntPath("typed.0.id"), 1)
+	equal(t, typed.IntPath("maps.0.id"), 2)
+}
+
+func Test_BoolPath(t *testing.T) {
+	typed := New(build("log", build("enabled", true, "nope", 1)))
+	equal(t, typed.BoolPath("log.enabled"), true)
+	equal(t, typed.BoolPathOr("log.other", true), true)
+	equal(t, typed.BoolPathMust("log.enabled"), true)
+
+	value, exists := typed.BoolPathIf("log.nope")
+	equal(t, value, false)
+	equal(t, exists, false)
+
+	defer mustTest(t, "expected boolean value for log.fail")
+	typed.BoolPathMust("log.fail")
+	t.FailNow()
+}
+
+func Test_IntPath(t *testing.T) {
+	typed := New(build("server", build("port", json.Number("84"), "string", "30", "nope", true)))
+	equal(t, typed.IntPath("server.port"), 84)
+	equal(t, typed.IntPath("server.string"), 30)
+	equal(t, typed.IntPath("server.other"), 0)
+	equal(t, typed.IntPathOr("server.other", 33), 33)
+	equal(t, typed.IntPathMust("server.port"), 84)
+
+	value, exists := typed.IntPathIf("server.nope")
+	equal(t, value, 0)
+	equal(t, exists, false)
+
+	defer mustTest(t, "expected int value for server.fail")
+	typed.IntPathMust("server.fail")
+	t.FailNow()
+}
+
+func Test_FloatPath(t *testing.T) {
+	typed := New(build("stats", build("pi", 3.14, "number", json.Number("32e-005"))))
+	equal(t, typed.FloatPath("stats.pi"), 3.14)
+	equal(t, typed.FloatPath("stats.number"), 0.00032)
+	equal(t, typed.FloatPathOr("stats.other", 1.1), 1.1)
+	equal(t, typed.FloatPathMust("stats.pi"), 3.14)
+
+	defer mustTest(t, "expected float value for stats.fail")
+	typed.FloatPathMust("stats.fail")
+	t.FailNow()
+}
+
+func Test_StringPath(t *testing.T) {
+	typed := New(build("server", build("host", "localhost", "nope", 1)))
+	equal(t, typed.StringPath("server.host"), "localhost")
+	equal(t, typed.StringPathOr("server.other", "openmymind.net"), "openmymind.net")
+	equal(t, typed.StringPathMust("server.host"), "localhost")
+
+	value, exists := typed.StringPathIf("server.nope")
+	equal(t, value, "")
+	equal(t, exists, false)
+
+	defer mustTest(t, "expected string value for server.fail")
+	typed.StringPathMust("server.fail")
+	t.FailNow()
+}
+
+func Test_TimePath(t *testing.T) {
+	now := time.Now().UTC()
+	zero := time.Time{}
+	typed := New(build("audit", build("ts", now)))
+	equal(t, typed.TimePath("audit.ts"), now)
+	equal(t, typed.TimePathOr("audit.other", zero), zero)
+	equal(t, typed.TimePath("audit.other"), zero)
+	equal(t, typed.TimePathMust("audit.ts"), now)
+
+	defer mustTest(t, "expected time.Time value for audit.fail")
+	typed.TimePathMust("audit.fail")
+	t.FailNow()
+}
+
+func Test_ObjectPath(t *testing.T) {
+	typed := New(build("server", build("tls", build("port", 443))))
+	equal(t, typed.ObjectPath("server.tls").Int("port"), 443)
+	equal(t, len(typed.ObjectPath("server.other")), 0)
+	equal(t, typed.ObjectPathOr("server.other", build("port", 1)).Int("port"), 1)
+	equal(t, typed.ObjectPathMust("server.tls").Int("port"), 443)
+
+	defer mustTest(t, "expected map for server.tls.port")
+	typed.ObjectPathMust("server.tls.port")
+	t.FailNow()
+}
+
+func Test_InterfacePath(t *testing.T) {
+	typed := New(build("server", build("host", "localhost")))
+	equal(t, typed.InterfacePath("server.host").(string), "localhost")
+	equal(t, typed.InterfacePathOr("server.other", "x").(string), "x")
+	equal(t, typed.InterfacePathMust("server.host").(string), "localhost")
+
+	defer mustTest(t, "expected map for server.fail")
+	typed.InterfacePathMust("server.fail")
+	t.FailNow()
+}
+
+func Test_MapPath(t *testing.T) {
+	typed := New(build("server", build("tls", build("port", 443))))
+	equal(t, typed.MapPath("server.tls")["port"], 443)
+	equal(t, typed.MapPathOr("server.other", build("port", 1))["port"], 1)
+
+	m, exists := typed.MapPathIf("server.tls.port")
+	equal(t, len(m), 0)
+	equal(t, exists, false)
+}
+
+func Test_SlicePaths(t *testing.T) {
+	typed := New(build("data", build(
+		"bools", []interface{}{true, false},
+		"ints", []interface{}{1, "2"},
+		"ints64", []interface{}{json.Number("3"), 4},
+		"floats", []interface{}{1.5, "2.5"},
+		"strings", []interface{}{"a", "b"},
+		"objects", []interface{}{build("id", 1)},
+	)))
+	equalList(t, typed.BoolsPath("data.bools"), []bool{true, false})
+	equalList(t, typed.IntsPath("data.ints"), []int{1, 2})
+	equalList(t, typed.Ints64Path("data.ints64"), []int64{3, 4})
+	equalList(t, typed.FloatsPath("data.floats"), []float64{1.5, 2.5})
+	equalList(t, typed.StringsPath("data.strings"), []string{"a", "b"})
+	equal(t, typed.ObjectsPath("data.objects")[0].Int("id"), 1)
+	equal(t, typed.ObjectsPathMust("data.objects")[0].Int("id"), 1)
+
+	equalList(t, typed.BoolsPathOr("data.other", []bool{true}), []bool{true})
+	equalList(t, typed.IntsPathOr("data.other", []int{9}), []int{9})
+	equalList(t, typed.Ints64PathOr("data.other", []int64{9}), []int64{9})
+	equalList(t, typed.FloatsPathOr("data.other", []float64{9}), []float64{9})
+	equalList(t, typed.StringsPathOr("data.other", []string{"z"}), []string{"z"})
+
+	_, exists := typed.ObjectsPathIf("data.other")
+	equal(t, exists, false)
+
+	defer mustTest(t, "expected objects value for data.other")
+	typed.ObjectsPathMust("data.other")
+	t.FailNow()
+}
+
+func Test_ParsePath(t *testing.T) {
+	segments, ok := parsePath("items[3].sku")
+	equal(t, ok, true)
+	equal(t, len(segments), 3)
+	equal(t, segments[0], segment{key: "items"})
+	equal(t, segments[1], segment{key: "3", index: true})
+	equal(t, segments[2], segment{key: "sku"})
+
+	segments, ok = parsePath("matrix[1][*]")
+	equal(t, ok, true)
+	equal(t, len(segments), 3)
+	equal(t, segments[2], segment{key: "*", index: true, wildcard: true})
+
+	for _, path := range []string{"items[3", "items[3]x"} {
+		_, ok = parsePath(path)
+		equal(t, ok, false)
+	}
+}
+
+func Test_PathIndex(t *testing.T) {
+	typed, _ := JsonString(`{"items": [{"sku": "a1"}, {"sku": "b2"}], "matrix": [[1, 2], [3, 4]]}`)
+	equal(t, typed.StringPath("items[1].sku"), "b2")
+	equal(t, typed.StringPath("items.0.sku"), "a1")
+	equal(t, typed.IntPath("matrix[1][0]"), 3)
+	equal(t, typed.ExistsPath("items[2].sku"), false)
+	equal(t, typed.ExistsPath("items[1"), false)
+}
+
+func Test_PathWildcard(t *testing.T) {
+	typed, _ := JsonString(`{"items": [{"price": 1.5, "tags": ["a"]}, {"price": "2"}, {"price": 3, "tags": ["b", "c"]}], "nope": 1}`)
+	equalList(t, typed.FloatsPath("items[*].price"), []float64{1.5, 2, 3})
+	equalList(t, typed.StringsPath("items[*].tags[*]"), []string{"a", "b", "c"})
+	equalList(t, typed.StringsPath("items[*].tags[0]"), []string{"a", "b"})
+	equal(t, len(typed.ObjectsPath("items[*]")), 3)
+
+	_, exists := typed.FloatPathIf("items[*].price")
+	equal(t, exists, false)
+
+	_, exists = typed.FloatsPathIf("nope[*]")
+	equal(t, exists, false)
+
+	typed = New(build("typed", []Typed{build("id", 1), build("id", 2)}, "maps", []map[string]interface{}{build("id", 3)}))
+	equalList(t, typed.IntsPath("typed[*].id"), []int{1, 2})
+	equalList(t, typed.IntsPath("maps[*].id"), []int{3})
+}
@@ -0,0 +1,302 @@
+package typed
+
+import (
+	"strings"
+)
+
+// Splits an RFC 6901 JSON Pointer (e.g. "/server/ports/0") into
+// its unescaped segments. The empty pointer references the
+// whole document. Returns false if the pointer is malformed.
+func parsePointer(pointer string) ([]segment, bool) {
+	if len(pointer) == 0 {
+		return nil, true
+	}
+	if pointer[0] != '/' {
+		return nil, false
+	}
+	parts := strings.Split(pointer[1:], "/")
+	segments := make([]segment, len(parts))
+	for i, part := range parts {
+		key, ok := unescapePointer(part)
+		if ok == false {
+			return nil, false
+		}
+		segments[i] = segment{key: key}
+	}
+	return segments, true
+}
+
+// Decodes the ~1 (/) and ~0 (~) escape sequences of a
+// pointer segment
+func unescapePointer(part string) (string, bool) {
+	if strings.IndexByte(part, '~') == -1 {
+		return part, true
+	}
+	var sb strings.Builder
+	for i := 0; i < len(part); i++ {
+		c := part[i]
+		if c != '~' {
+			sb.WriteByte(c)
+			continue
+		}
+		if i++; i == len(part) {
+			return "", false
+		}
+		switch part[i] {
+		case '0':
+			sb.WriteByte('~')
+		case '1':
+			sb.WriteByte('/')
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
+
+// Encodes a key so that it can be used as a segment of a pointer
+func escapePointer(key string) string {
+	if strings.IndexAny(key, "~/") == -1 {
+		return key
+	}
+	return strings.Replace(strings.Replace(key, "~", "~0", -1), "/", "~1", -1)
+}
+
+// Returns the value referenced by the JSON Pointer and
+// whether or not it existed
+func (t Typed) resolve(pointer string) (interface{}, bool) {
+	segments, ok := parsePointer(pointer)
+	if ok == false {
+		return nil, false
+	}
+	return walk(t, segments)
+}
+
+// Returns the value referenced by the JSON Pointer
+// or nil if it doesn't exist
+func (t Typed) Pointer(pointer string) interface{} {
+	return t.PointerOr(pointer, nil)
+}
+
+// Returns the value referenced by the JSON Pointer, or the
+// specified value if it doesn't exist
+func (t Typed) PointerOr(pointer string, d interface{}) interface{} {
+	if value, exists := t.PointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns the value referenced by the JSON Pointer or panics
+func (t Typed) PointerMust(pointer string) interface{} {
+	value, exists := t.PointerIf(pointer)
+	if exists == false {
+		panic(t.pointerError(pointer, ""))
+	}
+	return value
+}
+
+// Returns the value referenced by the JSON Pointer and
+// whether or not it existed
+func (t Typed) PointerIf(pointer string) (interface{}, bool) {
+	return t.resolve(pointer)
+}
+
+// Returns true if the JSON Pointer references an existing value
+func (t Typed) ExistsPointer(pointer string) bool {
+	_, exists := t.resolve(pointer)
+	return exists
+}
+
+// Returns a boolean referenced by the JSON Pointer, or false if it
+// doesn't exist, or if it isn't a bool
+func (t Typed) BoolPointer(pointer string) bool {
+	return t.BoolPointerOr(pointer, false)
+}
+
+// Returns a boolean referenced by the JSON Pointer, or the specified
+// value if it doesn't exist or isn't a bool
+func (t Typed) BoolPointerOr(pointer string, d bool) bool {
+	if value, exists := t.BoolPointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a bool referenced by the JSON Pointer or panics
+func (t Typed) BoolPointerMust(pointer string) bool {
+	b, exists := t.BoolPointerIf(pointer)
+	if exists == false {
+		panic(t.pointerError(pointer, "boolean"))
+	}
+	return b
+}
+
+// Returns a boolean referenced by the JSON Pointer and whether
+// or not it existed and the value was a boolean
+func (t Typed) BoolPointerIf(pointer string) (bool, bool) {
+	value, exists := t.resolve(pointer)
+	if exists == false {
+		return false, false
+	}
+	return toBool(value)
+}
+
+func (t Typed) IntPointer(pointer string) int {
+	return t.IntPointerOr(pointer, 0)
+}
+
+// Returns an int referenced by the JSON Pointer, or the specified
+// value if it doesn't exist or isn't an int
+func (t Typed) IntPointerOr(pointer string, d int) int {
+	if value, exists := t.IntPointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns an int referenced by the JSON Pointer or panics
+func (t Typed) IntPointerMust(pointer string) int {
+	i, exists := t.IntPointerIf(pointer)
+	if exists == false {
+		panic(t.pointerError(pointer, "int"))
+	}
+	return i
+}
+
+// Returns an int referenced by the JSON Pointer and whether
+// or not it existed and the value was an int
+func (t Typed) IntPointerIf(pointer string) (int, bool) {
+	value, exists := t.resolve(pointer)
+	if exists == false {
+		return 0, false
+	}
+	return toInt(value)
+}
+
+func (t Typed) FloatPointer(pointer string) float64 {
+	return t.FloatPointerOr(pointer, 0)
+}
+
+// Returns a float referenced by the JSON Pointer, or the specified
+// value if it doesn't exist or isn't a float
+func (t Typed) FloatPointerOr(pointer string, d float64) float64 {
+	if value, exists := t.FloatPointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a float referenced by the JSON Pointer or panics
+func (t Typed) FloatPointerMust(pointer string) float64 {
+	f, exists := t.FloatPointerIf(pointer)
+	if exists == false {
+		panic(t.pointerError(pointer, "float"))
+	}
+	return f
+}
+
+// Returns a float referenced by the JSON Pointer and whether
+// or not it existed and the value was a float
+func (t Typed) FloatPointerIf(pointer string) (float64, bool) {
+	value, exists := t.resolve(pointer)
+	if exists == false {
+		return 0, false
+	}
+	return toFloat(value)
+}
+
+func (t Typed) StringPointer(pointer string) string {
+	return t.StringPointerOr(pointer, "")
+}
+
+// Returns a string referenced by the JSON Pointer, or the specified
+// value if it doesn't exist or isn't a string
+func (t Typed) StringPointerOr(pointer string, d string) string {
+	if value, exists := t.StringPointerIf(pointer); exists {
+		return value
+	}
+	return d
+}
+
+// Returns a string referenced by the JSON Pointer or panics
+func (t Typed) StringPointerMust(pointer string) string {
+	s, exists := t.StringPointerIf(pointer)
+	if exists == false {
+		panic(t.pointerError(pointer, "string"))
+	}
+	return s
+}
+
+// Returns a string referenced by the JSON Pointer and whether
+// or not it existed and the value was a string
+func (t Typed) StringPointerIf(pointer string) (string, bool) {
+	value, exists := t.resolve(pointer)
+	if exists == false {
+		return "", false
+	}
+	return toString(value)
+}
+
+// Returns a Typed helper referenced by the JSON Pointer
+// If it doesn't exist, a default Typed helper is returned
+func (t Typed) ObjectPointer(pointer string) Typed {
+	o := t.ObjectPointerOr(pointer, nil)
+	if o == nil {
+		return Typed(nil)
+	}
+	return o
+}
+
+// Returns a Typed helper referenced by the JSON Pointer or the
+// specified default if it doesn't exist or isn't a map[string]interface{}
+func (t Typed) ObjectPointerOr(pointer string, d map[string]interface{}) Typed {
+	if value, exists := t.ObjectPointerIf(pointer); exists {
+		return value
+	}
+	return Typed(d)
+}
+
+// Returns a typed object referenced by the JSON Pointer or panics
+func (t Typed) ObjectPointerMust(pointer string) Typed {
+	o, exists := t.ObjectPointerIf(pointer)
+	if exists == false {
+		panic(t.pointerError(pointer, "map"))
+	}
+	return o
+}
+
+// Returns a Typed helper referenced by the JSON Pointer and whether
+// or not it existed and the value was a map[string]interface{}
+func (t Typed) ObjectPointerIf(pointer string) (Typed, bool) {
+	value, exists := t.resolve(pointer)
+	if exists == false {
+		return nil, false
+	}
+	return toObject(value)
+}
+
+// Returns a slice of Typed helpers referenced by the JSON Pointer,
+// or a nil slice
+func (t Typed) ObjectsPointer(pointer string) []Typed {
+	value, _ := t.ObjectsPointerIf(pointer)
+	return value
+}
+
+// Returns a slice of Typed helpers referenced by the JSON Pointer
+// and true if exists, otherwise; nil and false.
+func (t Typed) ObjectsPointerIf(pointer string) ([]Typed, bool) {
+	value, exists := t.resolve(pointer)
+	if exists == false {
+		return nil, false
+	}
+	return toObjects(value)
+}
+
+func (t Typed) ObjectsPointerMust(pointer string) []Typed {
+	value, exists := t.ObjectsPointerIf(pointer)
+	if exists == false {
+		panic(t.pointerError(pointer, "objects"))
+	}
+	return value
+}
@@ -0,0 +1,122 @@
+package typed
+
+import (
+	"testing"
+)
+
+func Test_ParsePointer(t *testing.T) {
+	segments, ok := parsePointer("")
+	equal(t, ok, true)
+	equal(t, len(segments), 0)
+
+	segments, ok = parsePointer("/a~1b/m~0n/~01/")
+	equal(t, ok, true)
+	equal(t, len(segments), 4)
+	equal(t, segments[0].key, "a/b")
+	equal(t, segments[1].key, "m~n")
+	equal(t, segments[2].key, "~1")
+	equal(t, segments[3].key, "")
+
+	for _, pointer := range []string{"a", "/a~", "/a~2"} {
+		_, ok = parsePointer(pointer)
+		equal(t, ok, false)
+	}
+}
+
+func Test_Pointer(t *testing.T) {
+	// the example document from RFC 6901
+	typed, _ := JsonString(`{"foo": ["bar", "baz"], "": 0, "a/b": 1, "c%d": 2, "e^f": 3, "g|h": 4, "i\\j": 5, "k\"l": 6, " ": 7, "m~n": 8}`)
+	equal(t, len(typed.Pointer("").(Typed)), 10)
+	equal(t, typed.StringPointer("/foo/0"), "bar")
+	equal(t, typed.IntPointer("/"), 0)
+	equal(t, typed.IntPointer("/a~1b"), 1)
+	equal(t, typed.IntPointer("/c%d"), 2)
+	equal(t, typed.IntPointer("/e^f"), 3)
+	equal(t, typed.IntPointer("/g|h"), 4)
+	equal(t, typed.IntPointer("/i\\j"), 5)
+	equal(t, typed.IntPointer("/k\"l"), 6)
+	equal(t, typed.IntPointer("/ "), 7)
+	equal(t, typed.IntPointer("/m~0n"), 8)
+
+	for _, pointer := range []string{"/foo/2", "/foo/-", "/foo/01", "/foo/+1", "/nope", "foo"} {
+		value, exists := typed.PointerIf(pointer)
+		equal(t, value, nil)
+		equal(t, exists, false)
+	}
+
+	equal(t, typed.PointerOr("/nope", "x").(string), "x")
+	equal(t, typed.PointerMust("/foo/1").(string), "baz")
+	equal(t, typed.ExistsPointer("/foo/1"), true)
+	equal(t, typed.ExistsPointer("/foo/3"), false)
+
+	defer mustTest(t, "expected map for /nope")
+	typed.PointerMust("/nope")
+	t.FailNow()
+}
+
+func Test_BoolPointer(t *testing.T) {
+	typed := New(build("log", build("enabled", true)))
+	equal(t, typed.BoolPointer("/log/enabled"), true)
+	equal(t, typed.BoolPointerOr("/log/other", true), true)
+	equal(t, typed.BoolPointerMust("/log/enabled"), true)
+
+	defer mustTest(t, "expected boolean value for /log/fail")
+	typed.BoolPointerMust("/log/fail")
+	t.FailNow()
+}
+
+func Test_IntPointer(t *testing.T) {
+	typed, _ := JsonString(`{"server": {"ports": [80, "443"]}}`)
+	equal(t, typed.IntPointer("/server/ports/0"), 80)
+	equal(t, typed.IntPointer("/server/ports/1"), 443)
+	equal(t, typed.IntPointerOr("/server/ports/2", 8080), 8080)
+	equal(t, typed.IntPointerMust("/server/ports/0"), 80)
+
+	defer mustTest(t, "expected int value for /server/ports")
+	typed.IntPointerMust("/server/ports")
+	t.FailNow()
+}
+
+func Test_FloatPointer(t *testing.T) {
+	typed, _ := JsonString(`{"stats": {"pi": 3.14}}`)
+	equal(t, typed.FloatPointer("/stats/pi"), 3.14)
+	equal(t, typed.FloatPointerOr("/stats/other", 1.1), 1.1)
+	equal(t, typed.FloatPointerMust("/stats/pi"), 3.14)
+
+	defer mustTest(t, "expected float value for /stats/fail")
+	typed.FloatPointerMust("/stats/fail")
+	t.FailNow()
+}
+
+func Test_StringPointer(t *testing.T) {
+	typed, _ := JsonString(`{"server": {"host": "localhost"}}`)
+	equal(t, typed.StringPointer("/server/host"), "localhost")
+	equal(t, typed.StringPointerOr("/server/other", "x"), "x")
+	equal(t, typed.StringPointerMust("/server/host"), "localhost")
+
+	defer mustTest(t, "expected string value for /server/fail")
+	typed.StringPointerMust("/server/fail")
+	t.FailNow()
+}
+
+func Test_ObjectPointer(t *testing.T) {
+	typed, _ := JsonString(`{"servers": [{"port": 80}, {"port": 81}]}`)
+	equal(t, typed.ObjectPointer("/servers/1").Int("port"), 81)
+	equal(t, len(typed.ObjectPointer("/servers/2")), 0)
+	equal(t, typed.ObjectPointerOr("/servers/2", build("port", 1)).Int("port"), 1)
+	equal(t, typed.ObjectPointerMust("/servers/0").Int("port"), 80)
+	equal(t, len(typed.ObjectsPointer("/servers")), 2)
+	equal(t, typed.ObjectsPointerMust("/servers")[1].Int("port"), 81)
+
+	_, exists := typed.ObjectsPointerIf("/servers/0")
+	equal(t, exists, false)
+
+	defer mustTest(t, "expected map for /servers")
+	typed.ObjectPointerMust("/servers")
+	t.FailNow()
+}
+
+func Test_EscapePointer(t *testing.T) {
+	equal(t, escapePointer("plain"), "plain")
+	equal(t, escapePointer("a/b~c"), "a~1b~0c")
+}
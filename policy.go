@@ -0,0 +1,519 @@
+package typed
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Controls which conversions between JSON types the accessors
+// of a Coerced make. Converting between numeric types (e.g. a
+// json.Number to an int) is always allowed.
+type Policy struct {
+	// Parse numeric strings ("42") for numeric accessors (including
+	// BigInt, BigFloat, Decimal and Duration)
+	StringToNumber bool
+
+	// Truncate numbers with a fractional part (2.1 is 2) for Int,
+	// rather than rejecting them. The fixed-width accessors
+	// (Int8 ... Uint64) never truncate
+	TruncateFloats bool
+
+	// Accept 1 and 0 for Bool
+	NumberToBool bool
+
+	// Strings accepted by Bool, matched case-insensitively
+	// (nil to never accept a string)
+	BoolStrings map[string]bool
+
+	// Format numbers and booleans for String
+	ToString bool
+
+	// Read numbers as Unix timestamps for Time
+	NumberToTime bool
+
+	// The unit of a duration given as a bare number (30 is 30
+	// seconds by default). Strings with a unit ("1500ms", "PT30S")
+	// aren't affected
+	DurationUnit time.Duration
+}
+
+// No conversion between JSON types: "42" isn't an int,
+// 2.1 isn't an int either and 80 isn't a time
+func Strict() Policy {
+	return Policy{}
+}
+
+// The conversions that Typed itself makes
+func Standard() Policy {
+	return Policy{StringToNumber: true, TruncateFloats: true, NumberToTime: true}
+}
+
+// Converts whenever it reasonably can: "yes", "on" and 1
+// are true and 42 is "42"
+func Lenient() Policy {
+	return Policy{
+		StringToNumber: true,
+		TruncateFloats: true,
+		NumberToBool:   true,
+		ToString:       true,
+		NumberToTime:   true,
+		BoolStrings: map[string]bool{
+			"true": true, "yes": true, "y": true, "on": true, "1": true,
+			"false": false, "no": false, "n": false, "off": false, "0": false,
+		},
+	}
+}
+
+// The policy of Typed's own accessors
+var standard = Standard()
+
+// Returns a Coerced which reads the same map as t but converts
+// values according to the policy
+func (t Typed) WithPolicy(p Policy) Coerced {
+	return Coerced{Typed: t, Policy: p}
+}
+
+// Whether the value can be read by a numeric accessor
+func (p Policy) numeric(value interface{}) bool {
+	_, isString := value.(string)
+	return isString == false || p.StringToNumber
+}
+
+func (p Policy) toBool(value interface{}) (bool, bool) {
+	switch t := value.(type) {
+	case bool:
+		return t, true
+	case string:
+		b, ok := p.BoolStrings[strings.ToLower(t)]
+		return b, ok
+	}
+	if p.NumberToBool {
+		if r, ok := toRat(value); ok && r.IsInt() && r.Num().IsInt64() {
+			switch r.Num().Int64() {
+			case 0:
+				return false, true
+			case 1:
+				return true, true
+			}
+		}
+	}
+	return false, false
+}
+
+func (p Policy) toInt(value interface{}) (int, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	if p.TruncateFloats {
+		return toInt(value)
+	}
+	i, ok := toInt64(value)
+	if ok == false || int64(int(i)) != i {
+		return 0, false
+	}
+	return int(i), true
+}
+
+func (p Policy) toFloat(value interface{}) (float64, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toFloat(value)
+}
+
+func (p Policy) toString(value interface{}) (string, bool) {
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	if p.ToString == false {
+		return "", false
+	}
+	switch t := value.(type) {
+	case bool:
+		return strconv.FormatBool(t), true
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32), true
+	}
+	if d, ok := toDecimal(value); ok {
+		return d, true
+	}
+	return "", false
+}
+
+// Strings with a unit ("1500ms", "PT30S") are always durations,
+// bare numeric strings ("30") only with StringToNumber. Numbers
+// are in DurationUnit
+func (p Policy) toDuration(value interface{}) (time.Duration, bool) {
+	if s, ok := value.(string); ok && p.StringToNumber == false {
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return 0, false
+		}
+	}
+	unit := p.DurationUnit
+	if unit == 0 {
+		unit = time.Second
+	}
+	return toDuration(value, unit)
+}
+
+func (p Policy) toTime(value interface{}) (time.Time, bool) {
+	switch value.(type) {
+	case time.Time, string:
+	default:
+		if p.NumberToTime == false {
+			return time.Time{}, false
+		}
+	}
+	return toTime(value)
+}
+
+func (p Policy) toBigInt(value interface{}) (*big.Int, bool) {
+	if p.numeric(value) == false {
+		return nil, false
+	}
+	return toBigInt(value)
+}
+
+func (p Policy) toBigFloat(value interface{}) (*big.Float, bool) {
+	if p.numeric(value) == false {
+		return nil, false
+	}
+	return toBigFloat(value)
+}
+
+func (p Policy) toDecimal(value interface{}) (string, bool) {
+	if p.numeric(value) == false {
+		return "", false
+	}
+	return toDecimal(value)
+}
+
+func (p Policy) toInt8(value interface{}) (int8, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toInt8(value)
+}
+
+func (p Policy) toInt16(value interface{}) (int16, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toInt16(value)
+}
+
+func (p Policy) toInt32(value interface{}) (int32, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toInt32(value)
+}
+
+func (p Policy) toInt64(value interface{}) (int64, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toInt64(value)
+}
+
+// The elements of Ints64, see toTruncatedInt64
+func (p Policy) toTruncatedInt64(value interface{}) (int64, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	if p.TruncateFloats {
+		return toTruncatedInt64(value)
+	}
+	return toInt64(value)
+}
+
+func (p Policy) toUint(value interface{}) (uint, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toUint(value)
+}
+
+func (p Policy) toUint8(value interface{}) (uint8, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toUint8(value)
+}
+
+func (p Policy) toUint16(value interface{}) (uint16, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toUint16(value)
+}
+
+func (p Policy) toUint32(value interface{}) (uint32, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toUint32(value)
+}
+
+func (p Policy) toUint64(value interface{}) (uint64, bool) {
+	if p.numeric(value) == false {
+		return 0, false
+	}
+	return toUint64(value)
+}
+
+func (p Policy) toBools(value interface{}) ([]bool, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]bool, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toBool(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toInts(value interface{}) ([]int, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]int, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toInt(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toInts64(value interface{}) ([]int64, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]int64, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toTruncatedInt64(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toFloats(value interface{}) ([]float64, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]float64, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toFloat(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toStrings(value interface{}) ([]string, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]string, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toString(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toInts8(value interface{}) ([]int8, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]int8, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toInt8(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toInts16(value interface{}) ([]int16, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]int16, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toInt16(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toInts32(value interface{}) ([]int32, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]int32, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toInt32(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toUints(value interface{}) ([]uint, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]uint, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toUint(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toUints8(value interface{}) ([]uint8, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]uint8, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toUint8(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toUints16(value interface{}) ([]uint16, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]uint16, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toUint16(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toUints32(value interface{}) ([]uint32, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]uint32, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toUint32(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toUints64(value interface{}) ([]uint64, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]uint64, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toUint64(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toTimes(value interface{}) ([]time.Time, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]time.Time, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toTime(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toDurations(value interface{}) ([]time.Duration, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]time.Duration, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toDuration(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toBigInts(value interface{}) ([]*big.Int, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]*big.Int, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toBigInt(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toBigFloats(value interface{}) ([]*big.Float, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]*big.Float, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toBigFloat(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+func (p Policy) toDecimals(value interface{}) ([]string, bool) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, false
+	}
+	n := make([]string, len(a))
+	for i, v := range a {
+		if n[i], ok = p.toDecimal(v); ok == false {
+			return n, false
+		}
+	}
+	return n, true
+}
@@ -0,0 +1,323 @@
+package typed
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_StrictPolicy(t *testing.T) {
+	typed, _ := JsonString(`{"id": 42, "str": "42", "frac": 2.5, "pi": "3.14", "bool": "true", "num": 1, "name": "leto", "ids": [1, "2"], "counts": {"a": 1, "b": "2"}}`)
+	strict := typed.WithPolicy(Strict())
+	equal(t, strict.Int("id"), 42)
+	equal(t, strict.IntOr("str", -1), -1)
+	equal(t, strict.IntOr("frac", -1), -1)
+	equal(t, strict.Float("frac"), 2.5)
+	equal(t, strict.FloatOr("pi", -1), -1.0)
+	equal(t, strict.BoolOr("bool", true), true)
+	_, ok := strict.BoolIf("bool")
+	equal(t, ok, false)
+	equal(t, strict.StringOr("num", "x"), "x")
+	equal(t, strict.String("name"), "leto")
+	equal(t, strict.Int64Or("str", -1), int64(-1))
+	equal(t, strict.Uint8("id"), uint8(42))
+
+	values, ok := strict.IntsIf("ids")
+	equalList(t, values, []int{1, 0})
+	equal(t, ok, false)
+	equal(t, strict.StringInt("counts") == nil, true)
+
+	// Typed itself is unaffected
+	equal(t, typed.Int("str"), 42)
+}
+
+func Test_LenientPolicy(t *testing.T) {
+	typed, _ := JsonString(`{"yes": "yes", "on": "ON", "one": 1, "zero": 0, "two": 2, "no": "off", "id": 42, "price": 1.50, "flag": true, "frac": 2.5, "tags": [1, "a", false], "flags": {"a": "y", "b": 0}}`)
+	lenient := typed.WithPolicy(Lenient())
+	equal(t, lenient.Bool("yes"), true)
+	equal(t, lenient.Bool("on"), true)
+	equal(t, lenient.Bool("one"), true)
+	equal(t, lenient.BoolOr("zero", true), false)
+	equal(t, lenient.BoolOr("no", true), false)
+	_, ok := lenient.BoolIf("two")
+	equal(t, ok, false)
+
+	equal(t, lenient.String("id"), "42")
+	equal(t, lenient.String("price"), "1.50")
+	equal(t, lenient.String("flag"), "true")
+	equal(t, New(build("f", 2.5)).WithPolicy(Lenient()).String("f"), "2.5")
+	equal(t, lenient.Int("frac"), 2)
+	equalList(t, lenient.Strings("tags"), []string{"1", "a", "false"})
+
+	flags, ok := lenient.StringBoolIf("flags")
+	equal(t, ok, true)
+	equal(t, flags["a"], true)
+	equal(t, flags["b"], false)
+	equal(t, lenient.BoolMust("yes"), true)
+}
+
+func Test_StandardPolicyMatchesTyped(t *testing.T) {
+	values := []interface{}{42, "42", 2.5, "2.5", json.Number("7"), json.Number("7.5"), true, "true", "leto", nil, uint64(1 << 63), "30s", "2021-03-04T05:06:07Z"}
+	for _, value := range values {
+		typed := New(build("v", value))
+		standard := typed.WithPolicy(Standard())
+		i1, ok1 := standard.IntIf("v")
+		i2, ok2 := typed.IntIf("v")
+		equal(t, i1, i2)
+		equal(t, ok1, ok2)
+
+		f1, ok1 := standard.FloatIf("v")
+		f2, ok2 := typed.FloatIf("v")
+		equal(t, f1, f2)
+		equal(t, ok1, ok2)
+
+		b1, ok1 := standard.BoolIf("v")
+		b2, ok2 := typed.BoolIf("v")
+		equal(t, b1, b2)
+		equal(t, ok1, ok2)
+
+		s1, ok1 := standard.StringIf("v")
+		s2, ok2 := typed.StringIf("v")
+		equal(t, s1, s2)
+		equal(t, ok1, ok2)
+
+		l1, ok1 := standard.Int64If("v")
+		l2, ok2 := typed.Int64If("v")
+		equal(t, l1, l2)
+		equal(t, ok1, ok2)
+
+		t1, ok1 := standard.TimeIf("v")
+		t2, ok2 := typed.TimeIf("v")
+		equal(t, t1, t2)
+		equal(t, ok1, ok2)
+
+		d1, ok1 := standard.DurationIf("v")
+		d2, ok2 := typed.DurationIf("v")
+		equal(t, d1, d2)
+		equal(t, ok1, ok2)
+
+		n1, ok1 := standard.BigIntIf("v")
+		n2, ok2 := typed.BigIntIf("v")
+		equal(t, n1.String(), n2.String())
+		equal(t, ok1, ok2)
+
+		s1, ok1 = standard.DecimalIf("v")
+		s2, ok2 = typed.DecimalIf("v")
+		equal(t, s1, s2)
+		equal(t, ok1, ok2)
+	}
+}
+
+func Test_StrictPolicyFamilies(t *testing.T) {
+	typed, _ := JsonString(`{"s": "42", "d": "30", "u": "1500ms", "n": 80, "t": "2021-03-04T05:06:07Z", "ns": ["1", "2"], "m": {"a": "1"}, "o": {"n": 80}}`)
+	strict := typed.WithPolicy(Strict())
+	_, ok := strict.DecimalIf("s")
+	equal(t, ok, false)
+	_, ok = strict.BigIntIf("s")
+	equal(t, ok, false)
+	_, ok = strict.BigFloatIf("s")
+	equal(t, ok, false)
+	_, ok = strict.DurationIf("d")
+	equal(t, ok, false)
+	equal(t, strict.Duration("u"), 1500*time.Millisecond)
+	equal(t, strict.Duration("n"), 80*time.Second)
+	_, ok = strict.TimeIf("n")
+	equal(t, ok, false)
+	_, ok = strict.TimePathIf("o.n")
+	equal(t, ok, false)
+	equal(t, strict.Time("t").Year(), 2021)
+
+	_, ok = strict.DecimalsIf("ns")
+	equal(t, ok, false)
+	_, ok = strict.BigIntsIf("ns")
+	equal(t, ok, false)
+	_, ok = strict.DurationsIf("ns")
+	equal(t, ok, false)
+	equal(t, strict.StringDecimal("m") == nil, true)
+	equal(t, strict.StringBigInt("m") == nil, true)
+
+	equal(t, typed.WithPolicy(Standard()).Decimal("s"), "42")
+	equal(t, typed.WithPolicy(Standard()).Duration("d"), 30*time.Second)
+
+	fractions := New(build("f", []interface{}{1.5, 2.0}))
+	_, ok = fractions.WithPolicy(Strict()).Ints64If("f")
+	equal(t, ok, false)
+	equalList(t, fractions.WithPolicy(Standard()).Ints64("f"), []int64{1, 2})
+}
+
+func Test_PolicyDecode(t *testing.T) {
+	typed, _ := JsonString(`{"id": "42", "server": {"port": "80"}, "counts": {"1": 2}}`)
+	var id int
+	err := typed.WithPolicy(Strict()).Decode("id", &id)
+	equal(t, err.Error(), "cannot decode string into int at id")
+	equal(t, typed.WithPolicy(Standard()).Decode("id", &id), nil)
+	equal(t, id, 42)
+
+	var v struct {
+		Server struct {
+			Port int `json:"port"`
+		} `json:"server"`
+	}
+	err = typed.WithPolicy(Strict()).DecodeAll(&v)
+	equal(t, err.Error(), "cannot decode string into int at server.port")
+
+	// an int truncates like Int, under TruncateFloats, while the
+	// fixed-width kinds are exact like Int8 ... Int64
+	var f struct {
+		N     int   `json:"n"`
+		Small int32 `json:"small"`
+	}
+	fractions := New(build("n", 2.5, "small", 2))
+	equal(t, fractions.DecodeAll(&f), nil)
+	equal(t, f.N, 2)
+	f.N = 0
+	equal(t, fractions.WithPolicy(Lenient()).DecodeAll(&f), nil)
+	equal(t, f.N, 2)
+	err = fractions.WithPolicy(Strict()).DecodeAll(&f)
+	equal(t, err.Error(), "cannot decode number into int at n")
+	fractions["small"] = 2.5
+	err = fractions.DecodeAll(&f)
+	equal(t, err.Error(), "cannot decode number into int32 at small")
+
+	// object keys are always strings
+	var counts map[int]int
+	equal(t, typed.WithPolicy(Strict()).Decode("counts", &counts), nil)
+	equal(t, counts[1], 2)
+}
+
+func Test_PolicyCollector(t *testing.T) {
+	typed, _ := JsonString(`{"port": "80", "server": {"tls": "on", "timeout": "30"}}`)
+	c := typed.WithPolicy(Strict()).Collector()
+	c.Int("port")
+	c.Object("server").DurationOr("timeout", 0)
+	equal(t, len(c.Errors()), 2)
+
+	c = typed.WithPolicy(Lenient()).Collector()
+	equal(t, c.Object("server").Bool("tls"), true)
+	equal(t, c.Int("port"), 80)
+	equal(t, c.Err(), nil)
+}
+
+func Test_PolicyPresetsAreCopies(t *testing.T) {
+	lenient := Lenient()
+	lenient.BoolStrings["si"] = true
+	delete(lenient.BoolStrings, "yes")
+	equal(t, Lenient().BoolStrings["si"], false)
+	equal(t, Lenient().BoolStrings["yes"], true)
+}
+
+// Every Typed accessor which converts a value must be overridden by
+// Coerced, otherwise the promoted method silently ignores the policy
+func Test_CoercedOverridesConversions(t *testing.T) {
+	// the methods which return the value as-is, or don't read one
+	independent := map[string]bool{
+		"Interface": true, "InterfaceOr": true, "InterfaceIf": true, "InterfaceMust": true, "InterfaceE": true,
+		"InterfacePath": true, "InterfacePathOr": true, "InterfacePathIf": true, "InterfacePathMust": true,
+		"Pointer": true, "PointerOr": true, "PointerIf": true, "PointerMust": true,
+		"Map": true, "MapOr": true, "MapIf": true, "MapPath": true, "MapPathOr": true, "MapPathIf": true,
+		"Maps": true, "MapsOr": true, "MapsIf": true,
+		"Exists": true, "ExistsPath": true, "ExistsPointer": true, "Keys": true,
+		"Set": true, "Delete": true, "SetPath": true, "DeletePath": true, "Clone": true,
+		"MergePatch": true, "Patch": true, "ToBytes": true, "MustBytes": true,
+		"WithPolicy": true,
+	}
+	// the methods which read a value the same way under any policy,
+	// which Coerced's documentation must list
+	unconverted := []string{"TimeLayout", "TimeLayoutIf", "Validate"}
+
+	methods := make(map[string]map[string]bool)
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return strings.HasSuffix(fi.Name(), "_test.go") == false
+	}, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc string
+	for _, file := range packages["typed"].Files {
+		for _, decl := range file.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+				if gd.Specs[0].(*ast.TypeSpec).Name.Name == "Coerced" {
+					doc = gd.Doc.Text()
+				}
+			}
+			fn, ok := decl.(*ast.FuncDecl)
+			if ok == false || fn.Recv == nil || fn.Name.IsExported() == false {
+				continue
+			}
+			if receiver, ok := fn.Recv.List[0].Type.(*ast.Ident); ok {
+				if methods[receiver.Name] == nil {
+					methods[receiver.Name] = make(map[string]bool)
+				}
+				methods[receiver.Name][fn.Name.Name] = true
+			}
+		}
+	}
+	for _, name := range unconverted {
+		independent[name] = true
+		listed := strings.Contains(doc, name+",") || strings.Contains(doc, name+" ")
+		if methods["Typed"][name] == false || listed == false {
+			t.Errorf("Coerced's documentation doesn't list %s", name)
+		}
+	}
+	for name := range methods["Typed"] {
+		if independent[name] == false && methods["Coerced"][name] == false {
+			t.Errorf("Coerced doesn't override %s", name)
+		}
+	}
+}
+
+func Test_CoercedUnconverted(t *testing.T) {
+	typed := New(build("at", "2021-03-04", "unix", 1614834367, "port", "80"))
+	strict, lenient := typed.WithPolicy(Strict()), typed.WithPolicy(Lenient())
+	equal(t, strict.TimeLayout("at", nil, "2006-01-02").Day(), 4)
+	_, ok := lenient.TimeLayoutIf("unix", nil, "2006-01-02")
+	equal(t, ok, false)
+	equal(t, lenient.Validate(Field("port").IsInt()).Error(), "port must be an integer")
+	equal(t, len(strict.Validate(Field("port").IsString())), 0)
+}
+
+func Test_CustomPolicy(t *testing.T) {
+	policy := Policy{BoolStrings: map[string]bool{"si": true, "no": false}}
+	typed := New(build("a", "SI", "b", "yes", "c", "42"))
+	custom := typed.WithPolicy(policy)
+	equal(t, custom.Bool("a"), true)
+	equal(t, custom.BoolOr("b", false), false)
+	equal(t, custom.IntOr("c", -1), -1)
+}
+
+func Test_PolicyNested(t *testing.T) {
+	typed, _ := JsonString(`{"server": {"port": "80", "tls": "on"}, "servers": [{"port": "81"}], "named": {"a": {"port": "82"}}}`)
+	strict := typed.WithPolicy(Strict())
+	lenient := typed.WithPolicy(Lenient())
+
+	equal(t, strict.Object("server").IntOr("port", -1), -1)
+	equal(t, lenient.Object("server").Bool("tls"), true)
+	equal(t, strict.ObjectMust("server").IntOr("port", -1), -1)
+	equal(t, strict.Objects("servers")[0].IntOr("port", -1), -1)
+	equal(t, strict.StringObject("named")["a"].IntOr("port", -1), -1)
+	equal(t, lenient.StringObject("named")["a"].Int("port"), 82)
+	equal(t, strict.Object("other").Int("port"), 0)
+	equal(t, len(strict.Objects("other")), 0)
+
+	equal(t, strict.IntPathOr("server.port", -1), -1)
+	equal(t, lenient.BoolPath("server.tls"), true)
+	equal(t, strict.ObjectPath("server").IntOr("port", -1), -1)
+	equal(t, strict.IntPointerOr("/server/port", -1), -1)
+	equal(t, lenient.BoolPointer("/server/tls"), true)
+	equal(t, strict.ObjectsPath("servers")[0].IntOr("port", -1), -1)
+	equalList(t, lenient.IntsPath("servers[*].port"), []int{81})
+	_, ok := strict.IntsPathIf("servers[*].port")
+	equal(t, ok, false)
+}
+
+func Test_PolicyMust(t *testing.T) {
+	defer mustTest(t, "expected int value for port")
+	New(build("port", "80")).WithPolicy(Strict()).IntMust("port")
+	t.FailNow()
+}
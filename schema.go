@@ -0,0 +1,584 @@
+package typed
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// A compiled JSON Schema (draft 2020-12), see CompileSchema
+type Schema struct {
+	// set for the boolean schemas true and false
+	always *bool
+
+	types             []string
+	properties        map[string]*Schema
+	patternProperties []patternSchema
+	additional        *Schema
+	required          []string
+	prefixItems       []*Schema
+	items             *Schema
+	enum              []interface{}
+	constant          interface{}
+	hasConst          bool
+	pattern           *regexp.Regexp
+	minimum           *bound
+	maximum           *bound
+	exclusiveMinimum  *bound
+	exclusiveMaximum  *bound
+	multipleOf        *bound
+	minLength         int
+	maxLength         int
+	minItems          int
+	maxItems          int
+	allOf             []*Schema
+	anyOf             []*Schema
+	oneOf             []*Schema
+	not               *Schema
+	ref               *Schema
+
+	// the JSON Pointer of the schema within the root schema
+	location string
+}
+
+type patternSchema struct {
+	pattern *regexp.Regexp
+	schema  *Schema
+}
+
+type bound struct {
+	value *big.Rat
+	text  string
+}
+
+// A value which doesn't satisfy a schema
+type SchemaError struct {
+	// The JSON Pointer of the value within the document
+	// ("" for the document itself)
+	Location string
+	// The JSON Pointer of the keyword which failed within
+	// the schema (e.g. "/properties/port/maximum")
+	Keyword string
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	location := e.Location
+	if location == "" {
+		location = "(root)"
+	}
+	return location + ": " + e.Message
+}
+
+// The errors returned by Schema.Validate
+type SchemaErrors []SchemaError
+
+func (e SchemaErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+type schemaCompiler struct {
+	root     Typed
+	compiled map[string]*Schema
+}
+
+// Compiles a JSON Schema (draft 2020-12), typically loaded with
+// JsonFile or Json. The supported keywords are type, properties,
+// patternProperties, additionalProperties, required, prefixItems,
+// items, minItems, maxItems, enum, const, pattern, minLength,
+// maxLength, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// multipleOf, allOf, anyOf, oneOf, not and $ref, which must be
+// local to the schema ("#" or "#/$defs/address"). Other keywords
+// (title, format, ...) are ignored.
+func CompileSchema(schema Typed) (*Schema, error) {
+	c := &schemaCompiler{root: schema, compiled: make(map[string]*Schema)}
+	s, err := c.compile(map[string]interface{}(schema), "")
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkLoops(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Returns an error if a schema applies itself to the same value,
+// through $ref, allOf, anyOf, oneOf or not, without first moving
+// into a property or an element: validating would never end
+func (c *schemaCompiler) checkLoops() error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	states := make(map[*Schema]int, len(c.compiled))
+	var visit func(s *Schema) error
+	visit = func(s *Schema) error {
+		switch states[s] {
+		case visiting:
+			return schemaCompileError(s.location, "$ref loops back to this schema without validating a property or an element")
+		case done:
+			return nil
+		}
+		states[s] = visiting
+		for _, next := range s.inPlace() {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		states[s] = done
+		return nil
+	}
+
+	locations := make([]string, 0, len(c.compiled))
+	for location := range c.compiled {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	for _, location := range locations {
+		if err := visit(c.compiled[location]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the schemas which apply to the same value as this one
+func (s *Schema) inPlace() []*Schema {
+	var schemas []*Schema
+	if s.ref != nil {
+		schemas = append(schemas, s.ref)
+	}
+	if s.not != nil {
+		schemas = append(schemas, s.not)
+	}
+	schemas = append(schemas, s.allOf...)
+	schemas = append(schemas, s.anyOf...)
+	return append(schemas, s.oneOf...)
+}
+
+func (c *schemaCompiler) compile(value interface{}, location string) (*Schema, error) {
+	if s, exists := c.compiled[location]; exists {
+		return s, nil
+	}
+	s := &Schema{location: location, minLength: -1, maxLength: -1, minItems: -1, maxItems: -1}
+	c.compiled[location] = s
+
+	if b, ok := value.(bool); ok {
+		s.always = &b
+		return s, nil
+	}
+	o, ok := toObject(value)
+	if ok == false {
+		return nil, schemaCompileError(location, "a schema must be an object or a boolean")
+	}
+
+	var err error
+	for keyword, v := range o {
+		at := location + "/" + escapePointer(keyword)
+		switch keyword {
+		case "type":
+			s.types, err = compileTypes(v, at)
+		case "properties":
+			s.properties, err = c.compileProperties(v, at)
+		case "patternProperties":
+			s.patternProperties, err = c.compilePatternProperties(v, at)
+		case "additionalProperties":
+			s.additional, err = c.compile(v, at)
+		case "required":
+			s.required, err = compileStrings(v, at)
+		case "prefixItems":
+			s.prefixItems, err = c.compileList(v, at)
+		case "items":
+			s.items, err = c.compile(v, at)
+		case "enum":
+			var isArray bool
+			if s.enum, isArray = toArray(v); isArray == false {
+				err = schemaCompileError(at, "must be an array")
+			}
+		case "const":
+			s.constant, s.hasConst = v, true
+		case "pattern":
+			s.pattern, err = compilePattern(v, at)
+		case "minimum":
+			s.minimum, err = compileBound(v, at)
+		case "maximum":
+			s.maximum, err = compileBound(v, at)
+		case "exclusiveMinimum":
+			s.exclusiveMinimum, err = compileBound(v, at)
+		case "exclusiveMaximum":
+			s.exclusiveMaximum, err = compileBound(v, at)
+		case "multipleOf":
+			if s.multipleOf, err = compileBound(v, at); err == nil && s.multipleOf.value.Sign() <= 0 {
+				err = schemaCompileError(at, "must be greater than 0")
+			}
+		case "minLength":
+			s.minLength, err = compileCount(v, at)
+		case "maxLength":
+			s.maxLength, err = compileCount(v, at)
+		case "minItems":
+			s.minItems, err = compileCount(v, at)
+		case "maxItems":
+			s.maxItems, err = compileCount(v, at)
+		case "allOf":
+			s.allOf, err = c.compileList(v, at)
+		case "anyOf":
+			s.anyOf, err = c.compileList(v, at)
+		case "oneOf":
+			s.oneOf, err = c.compileList(v, at)
+		case "not":
+			s.not, err = c.compile(v, at)
+		case "$ref":
+			s.ref, err = c.compileRef(v, at)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (c *schemaCompiler) compileRef(value interface{}, location string) (*Schema, error) {
+	ref, ok := value.(string)
+	if ok == false || strings.HasPrefix(ref, "#") == false {
+		return nil, schemaCompileError(location, "only local references (#/...) are supported")
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, schemaCompileError(location, "invalid reference "+ref)
+	}
+	segments, ok := parsePointer(pointer)
+	if ok == false {
+		return nil, schemaCompileError(location, "invalid reference "+ref)
+	}
+	target, exists := walk(c.root, segments)
+	if exists == false {
+		return nil, schemaCompileError(location, "unresolved reference "+ref)
+	}
+	return c.compile(target, pointer)
+}
+
+func (c *schemaCompiler) compileProperties(value interface{}, location string) (map[string]*Schema, error) {
+	o, ok := toObject(value)
+	if ok == false {
+		return nil, schemaCompileError(location, "must be an object")
+	}
+	properties := make(map[string]*Schema, len(o))
+	for name, v := range o {
+		s, err := c.compile(v, location+"/"+escapePointer(name))
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = s
+	}
+	return properties, nil
+}
+
+func (c *schemaCompiler) compilePatternProperties(value interface{}, location string) ([]patternSchema, error) {
+	o, ok := toObject(value)
+	if ok == false {
+		return nil, schemaCompileError(location, "must be an object")
+	}
+	patterns := make([]patternSchema, 0, len(o))
+	for _, expr := range sortedKeys(o) {
+		at := location + "/" + escapePointer(expr)
+		pattern, err := compilePattern(expr, at)
+		if err != nil {
+			return nil, err
+		}
+		s, err := c.compile(o[expr], at)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, patternSchema{pattern, s})
+	}
+	return patterns, nil
+}
+
+func (c *schemaCompiler) compileList(value interface{}, location string) ([]*Schema, error) {
+	a, ok := toArray(value)
+	if ok == false || len(a) == 0 {
+		return nil, schemaCompileError(location, "must be a non-empty array")
+	}
+	schemas := make([]*Schema, len(a))
+	for i, v := range a {
+		s, err := c.compile(v, fmt.Sprintf("%s/%d", location, i))
+		if err != nil {
+			return nil, err
+		}
+		schemas[i] = s
+	}
+	return schemas, nil
+}
+
+var schemaTypes = map[string]bool{"null": true, "boolean": true, "object": true, "array": true, "number": true, "string": true, "integer": true}
+
+func compileTypes(value interface{}, location string) ([]string, error) {
+	if t, ok := value.(string); ok {
+		value = []interface{}{t}
+	}
+	types, err := compileStrings(value, location)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range types {
+		if schemaTypes[t] == false {
+			return nil, schemaCompileError(location, "unknown type "+t)
+		}
+	}
+	return types, nil
+}
+
+func compileStrings(value interface{}, location string) ([]string, error) {
+	a, ok := toArray(value)
+	if ok == false {
+		return nil, schemaCompileError(location, "must be an array of strings")
+	}
+	values := make([]string, len(a))
+	for i, v := range a {
+		if values[i], ok = v.(string); ok == false {
+			return nil, schemaCompileError(location, "must be an array of strings")
+		}
+	}
+	return values, nil
+}
+
+func compilePattern(value interface{}, location string) (*regexp.Regexp, error) {
+	expr, ok := value.(string)
+	if ok == false {
+		return nil, schemaCompileError(location, "must be a string")
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, schemaCompileError(location, err.Error())
+	}
+	return pattern, nil
+}
+
+func compileBound(value interface{}, location string) (*bound, error) {
+	r, ok := toNumber(value)
+	if ok == false {
+		return nil, schemaCompileError(location, "must be a number")
+	}
+	return &bound{value: r, text: fmt.Sprint(value)}, nil
+}
+
+func compileCount(value interface{}, location string) (int, error) {
+	r, ok := toNumber(value)
+	if ok == false || r.IsInt() == false || r.Sign() < 0 || r.Num().IsInt64() == false {
+		return 0, schemaCompileError(location, "must be a non-negative integer")
+	}
+	return int(r.Num().Int64()), nil
+}
+
+func schemaCompileError(location string, message string) error {
+	return fmt.Errorf("typed: invalid schema at %q: %s", location, message)
+}
+
+// Validates the document against the schema and returns
+// every error (nil if the document is valid)
+func (s *Schema) Validate(t Typed) SchemaErrors {
+	return s.validate(map[string]interface{}(t), "", nil)
+}
+
+// Whether the document is valid
+func (s *Schema) Valid(t Typed) bool {
+	return len(s.Validate(t)) == 0
+}
+
+func (s *Schema) validate(value interface{}, location string, errors SchemaErrors) SchemaErrors {
+	fail := func(keyword string, format string, args ...interface{}) SchemaErrors {
+		return append(errors, SchemaError{Location: location, Keyword: s.location + "/" + keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.always != nil {
+		if *s.always == false {
+			errors = append(errors, SchemaError{Location: location, Keyword: s.location, Message: "is not allowed"})
+		}
+		return errors
+	}
+
+	if s.ref != nil {
+		errors = s.ref.validate(value, location, errors)
+	}
+
+	if s.types != nil && matchesType(value, s.types) == false {
+		errors = fail("type", "must be %s, got %s", strings.Join(s.types, " or "), jsonType(value))
+	}
+
+	if s.enum != nil {
+		found := false
+		for _, e := range s.enum {
+			if equalValues(value, e) {
+				found = true
+				break
+			}
+		}
+		if found == false {
+			errors = fail("enum", "must be one of %s", marshalSchemaValue(s.enum))
+		}
+	}
+	if s.hasConst && equalValues(value, s.constant) == false {
+		errors = fail("const", "must be %s", marshalSchemaValue(s.constant))
+	}
+
+	switch jsonType(value) {
+	case "number":
+		errors = s.validateNumber(value, fail, errors)
+	case "string":
+		str := value.(string)
+		l := utf8.RuneCountInString(str)
+		if s.minLength != -1 && l < s.minLength {
+			errors = fail("minLength", "must have at least %d characters", s.minLength)
+		}
+		if s.maxLength != -1 && l > s.maxLength {
+			errors = fail("maxLength", "must have at most %d characters", s.maxLength)
+		}
+		if s.pattern != nil && s.pattern.MatchString(str) == false {
+			errors = fail("pattern", "must match %s", s.pattern)
+		}
+	case "object":
+		o, _ := toObject(value)
+		errors = s.validateObject(o, location, fail, errors)
+	case "array":
+		a, _ := toArray(value)
+		errors = s.validateArray(a, location, fail, errors)
+	}
+
+	for _, sub := range s.allOf {
+		errors = sub.validate(value, location, errors)
+	}
+	if s.anyOf != nil && s.matches(s.anyOf, value) == 0 {
+		errors = fail("anyOf", "must match at least one schema")
+	}
+	if s.oneOf != nil {
+		if matched := s.matches(s.oneOf, value); matched != 1 {
+			errors = fail("oneOf", "must match exactly one schema, matched %d", matched)
+		}
+	}
+	if s.not != nil && len(s.not.validate(value, location, nil)) == 0 {
+		errors = fail("not", "must not match the schema")
+	}
+	return errors
+}
+
+func (s *Schema) matches(schemas []*Schema, value interface{}) int {
+	matched := 0
+	for _, sub := range schemas {
+		if len(sub.validate(value, "", nil)) == 0 {
+			matched++
+		}
+	}
+	return matched
+}
+
+func (s *Schema) validateNumber(value interface{}, fail func(string, string, ...interface{}) SchemaErrors, errors SchemaErrors) SchemaErrors {
+	if s.minimum != nil {
+		if c, _ := compareNumber(value, s.minimum.value); c < 0 {
+			errors = fail("minimum", "must be >= %s", s.minimum.text)
+		}
+	}
+	if s.maximum != nil {
+		if c, _ := compareNumber(value, s.maximum.value); c > 0 {
+			errors = fail("maximum", "must be <= %s", s.maximum.text)
+		}
+	}
+	if s.exclusiveMinimum != nil {
+		if c, _ := compareNumber(value, s.exclusiveMinimum.value); c <= 0 {
+			errors = fail("exclusiveMinimum", "must be > %s", s.exclusiveMinimum.text)
+		}
+	}
+	if s.exclusiveMaximum != nil {
+		if c, _ := compareNumber(value, s.exclusiveMaximum.value); c >= 0 {
+			errors = fail("exclusiveMaximum", "must be < %s", s.exclusiveMaximum.text)
+		}
+	}
+	if s.multipleOf != nil && isMultiple(value, s.multipleOf.value) == false {
+		errors = fail("multipleOf", "must be a multiple of %s", s.multipleOf.text)
+	}
+	return errors
+}
+
+func (s *Schema) validateObject(o Typed, location string, fail func(string, string, ...interface{}) SchemaErrors, errors SchemaErrors) SchemaErrors {
+	for _, name := range s.required {
+		if _, exists := o[name]; exists == false {
+			errors = fail("required", "missing property %q", name)
+		}
+	}
+	for _, name := range sortedKeys(o) {
+		at := location + "/" + escapePointer(name)
+		matched := false
+		if property, exists := s.properties[name]; exists {
+			matched = true
+			errors = property.validate(o[name], at, errors)
+		}
+		for _, p := range s.patternProperties {
+			if p.pattern.MatchString(name) {
+				matched = true
+				errors = p.schema.validate(o[name], at, errors)
+			}
+		}
+		if matched == false && s.additional != nil {
+			errors = s.additional.validate(o[name], at, errors)
+		}
+	}
+	return errors
+}
+
+func (s *Schema) validateArray(a []interface{}, location string, fail func(string, string, ...interface{}) SchemaErrors, errors SchemaErrors) SchemaErrors {
+	if s.minItems != -1 && len(a) < s.minItems {
+		errors = fail("minItems", "must have at least %d items", s.minItems)
+	}
+	if s.maxItems != -1 && len(a) > s.maxItems {
+		errors = fail("maxItems", "must have at most %d items", s.maxItems)
+	}
+	for i, element := range a {
+		at := fmt.Sprintf("%s/%d", location, i)
+		if i < len(s.prefixItems) {
+			errors = s.prefixItems[i].validate(element, at, errors)
+		} else if s.items != nil {
+			errors = s.items.validate(element, at, errors)
+		}
+	}
+	return errors
+}
+
+func matchesType(value interface{}, types []string) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual {
+			return true
+		}
+		if t == "integer" && actual == "number" {
+			if isInteger(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func marshalSchemaValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func sortedKeys(o map[string]interface{}) []string {
+	keys := make([]string, 0, len(o))
+	for key := range o {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
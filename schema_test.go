@@ -0,0 +1,213 @@
+package typed
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["name", "server"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 1, "maxLength": 5},
+		"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+		"ratio": {"type": "number", "exclusiveMinimum": 0, "maximum": 1, "multipleOf": 0.25},
+		"email": {"type": "string", "pattern": "^[^@]+@[^@]+$"},
+		"role": {"enum": ["admin", "user"]},
+		"version": {"const": 2},
+		"nick": {"type": ["string", "null"]},
+		"server": {"$ref": "#/$defs/server"},
+		"servers": {"type": "array", "items": {"$ref": "#/$defs/server"}, "maxItems": 2},
+		"point": {"type": "array", "prefixItems": [{"type": "number"}, {"type": "number"}], "items": false},
+		"to": {"oneOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}, "minItems": 1}]},
+		"id": {"anyOf": [{"type": "integer"}, {"type": "string", "pattern": "^[0-9]+$"}]},
+		"mode": {"allOf": [{"type": "string"}, {"not": {"const": "off"}}]},
+		"labels": {"type": "object", "patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": {"type": "integer"}},
+		"a/b": {"type": "boolean"},
+		"tree": {"$ref": "#/$defs/node"}
+	},
+	"$defs": {
+		"server": {
+			"type": "object",
+			"required": ["host"],
+			"properties": {
+				"host": {"type": "string"},
+				"port": {"type": "integer", "minimum": 1, "maximum": 65535}
+			}
+		},
+		"node": {
+			"type": "object",
+			"properties": {
+				"value": {"type": "integer"},
+				"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}
+			}
+		}
+	}
+}`
+
+func compileTestSchema(t *testing.T) *Schema {
+	t.Helper()
+	schema, _ := JsonString(testSchema)
+	s, err := CompileSchema(schema)
+	equal(t, err, nil)
+	return s
+}
+
+func Test_SchemaValid(t *testing.T) {
+	s := compileTestSchema(t)
+	typed, _ := JsonString(`{"name": "leto", "age": 30, "ratio": 0.75, "email": "leto@dune.gov", "role": "admin", "version": 2.0,
+		"nick": null, "server": {"host": "localhost", "port": 80}, "servers": [{"host": "a"}], "point": [1, 2.5], "to": ["a"],
+		"id": "12", "mode": "on", "labels": {"x-team": "core", "count": 3}, "a/b": true,
+		"tree": {"value": 1, "children": [{"value": 2, "children": []}]}}`)
+	equal(t, len(s.Validate(typed)), 0)
+	equal(t, s.Valid(typed), true)
+
+	typed, _ = JsonString(`{"name": "é", "server": {"host": "h"}, "to": "a", "id": 1}`)
+	equal(t, s.Valid(typed), true)
+}
+
+func Test_SchemaErrors(t *testing.T) {
+	s := compileTestSchema(t)
+	typed, _ := JsonString(`{"name": "", "age": 150, "ratio": 0.3, "email": "leto", "role": "root", "version": 3,
+		"nick": 1, "server": {"port": 0}, "servers": [{"host": 1}, {"host": "b"}, {"host": "c"}], "point": [1, 2, 3],
+		"to": [], "id": "x", "mode": "off", "labels": {"x-team": 1, "count": "3"}, "a/b": "yes", "extra": 1,
+		"tree": {"children": [{"value": "2"}]}}`)
+	errors := s.Validate(typed)
+	expected := []SchemaError{
+		{"/a~1b", "/properties/a~1b/type", "must be boolean, got string"},
+		{"/age", "/properties/age/exclusiveMaximum", "must be < 150"},
+		{"/email", "/properties/email/pattern", "must match ^[^@]+@[^@]+$"},
+		{"/extra", "/additionalProperties", "is not allowed"},
+		{"/id", "/properties/id/anyOf", "must match at least one schema"},
+		{"/labels/count", "/properties/labels/additionalProperties/type", "must be integer, got string"},
+		{"/labels/x-team", "/properties/labels/patternProperties/^x-/type", "must be string, got number"},
+		{"/mode", "/properties/mode/allOf/1/not", "must not match the schema"},
+		{"/name", "/properties/name/minLength", "must have at least 1 characters"},
+		{"/nick", "/properties/nick/type", "must be string or null, got number"},
+		{"/point/2", "/properties/point/items", "is not allowed"},
+		{"/ratio", "/properties/ratio/multipleOf", "must be a multiple of 0.25"},
+		{"/role", "/properties/role/enum", `must be one of ["admin","user"]`},
+		{"/server", "/$defs/server/required", `missing property "host"`},
+		{"/server/port", "/$defs/server/properties/port/minimum", "must be >= 1"},
+		{"/servers", "/properties/servers/maxItems", "must have at most 2 items"},
+		{"/servers/0/host", "/$defs/server/properties/host/type", "must be string, got number"},
+		{"/to", "/properties/to/oneOf", "must match exactly one schema, matched 0"},
+		{"/tree/children/0/value", "/$defs/node/properties/value/type", "must be integer, got string"},
+		{"/version", "/properties/version/const", "must be 2"},
+	}
+	equal(t, len(errors), len(expected))
+	for i, e := range errors {
+		equal(t, e, expected[i])
+	}
+}
+
+func Test_SchemaRoot(t *testing.T) {
+	schema, _ := JsonString(`{"required": ["id"], "properties": {"id": {"type": "integer"}}}`)
+	s, _ := CompileSchema(schema)
+	errors := s.Validate(New(build("id", 1.5)))
+	equal(t, errors.Error(), "/id: must be integer, got number")
+
+	errors = s.Validate(New(build("name", "leto")))
+	equal(t, errors.Error(), `(root): missing property "id"`)
+
+	schema, _ = JsonString(`{"oneOf": [{"required": ["a"]}, {"required": ["b"]}]}`)
+	s, _ = CompileSchema(schema)
+	equal(t, s.Valid(New(build("a", 1))), true)
+	equal(t, s.Validate(New(build("a", 1, "b", 2))).Error(), "(root): must match exactly one schema, matched 2")
+}
+
+func Test_SchemaCompileErrors(t *testing.T) {
+	for schema, expected := range map[string]string{
+		`{"type": "text"}`:            `"/type": unknown type text`,
+		`{"type": 1}`:                 `"/type": must be an array of strings`,
+		`{"properties": {"a": 1}}`:    `"/properties/a": a schema must be an object or a boolean`,
+		`{"pattern": "("}`:            `"/pattern": error parsing regexp`,
+		`{"minimum": "1"}`:            `"/minimum": must be a number`,
+		`{"minLength": -1}`:           `"/minLength": must be a non-negative integer`,
+		`{"multipleOf": 0}`:           `"/multipleOf": must be greater than 0`,
+		`{"anyOf": []}`:               `"/anyOf": must be a non-empty array`,
+		`{"$ref": "other.json#/a"}`:   `"/$ref": only local references`,
+		`{"$ref": "#/$defs/missing"}`: `"/$ref": unresolved reference #/$defs/missing`,
+		`{"$defs": {"a": {"type": 1}}, "$ref": "#/$defs/a"}`: `"/$defs/a/type": must be an array of strings`,
+	} {
+		typed, _ := JsonString(schema)
+		_, err := CompileSchema(typed)
+		if err == nil || strings.Contains(err.Error(), expected) == false {
+			t.Errorf("expected %s to fail with %s, got %v", schema, expected, err)
+		}
+	}
+}
+
+func Test_SchemaRecursiveRoot(t *testing.T) {
+	schema, _ := JsonString(`{"properties": {"name": {"type": "string"}, "child": {"$ref": "#"}}}`)
+	s, err := CompileSchema(schema)
+	equal(t, err, nil)
+	typed, _ := JsonString(`{"name": "a", "child": {"name": "b", "child": {"name": 3}}}`)
+	equal(t, s.Validate(typed).Error(), "/child/child/name: must be string, got number")
+}
+
+func Test_SchemaRefLoops(t *testing.T) {
+	for schema, expected := range map[string]string{
+		`{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`: `"/$defs/a": $ref loops back`,
+		`{"$ref": "#"}`: `"": $ref loops back`,
+		`{"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"allOf": [{"$ref": "#/$defs/a"}]}}, "$ref": "#/$defs/a"}`: `$ref loops back`,
+		`{"properties": {"a": {"anyOf": [{"type": "string"}, {"not": {"$ref": "#/properties/a"}}]}}}`:           `$ref loops back`,
+	} {
+		typed, _ := JsonString(schema)
+		_, err := CompileSchema(typed)
+		if err == nil || strings.Contains(err.Error(), expected) == false {
+			t.Errorf("expected %s to fail with %s, got %v", schema, expected, err)
+		}
+	}
+
+	// recursion through a property or an element is fine
+	for _, schema := range []string{
+		`{"properties": {"child": {"$ref": "#"}}}`,
+		`{"$defs": {"a": {"items": {"$ref": "#/$defs/a"}}}, "allOf": [{"$ref": "#/$defs/a"}]}`,
+	} {
+		typed, _ := JsonString(schema)
+		_, err := CompileSchema(typed)
+		equal(t, err, nil)
+	}
+}
+
+func Test_SchemaHugeExponents(t *testing.T) {
+	schema, _ := JsonString(`{"properties": {
+		"int": {"type": "integer"},
+		"min": {"minimum": 0, "exclusiveMaximum": 1},
+		"max": {"maximum": 1e300},
+		"multiple": {"multipleOf": 0.25},
+		"three": {"multipleOf": 3}
+	}}`)
+	s, err := CompileSchema(schema)
+	equal(t, err, nil)
+
+	valid := []string{
+		`{"int": 1e2000}`, `{"int": -2.5E+1000000000}`, `{"int": 1.25e1001}`, `{"int": 0e-99999999999999999999}`,
+		`{"int": 1` + strings.Repeat("0", 1002) + `e-1002}`,
+		`{"min": 1e-2000}`, `{"min": 1e-1000000000000}`, `{"max": -1e2000}`,
+		`{"multiple": 1e2000}`, `{"multiple": 75` + strings.Repeat("0", 1000) + `e-1002}`, `{"three": 3e2000}`,
+	}
+	for _, data := range valid {
+		typed, _ := JsonString(data)
+		equal(t, s.Validate(typed).Error(), "")
+	}
+
+	invalid := map[string]string{
+		`{"int": 100e-1002}`:                 "/int: must be integer, got number",
+		`{"int": 1e-2000}`:                   "/int: must be integer, got number",
+		`{"min": -1e-1000000000000}`:         "/min: must be >= 0",
+		`{"min": 1e2000}`:                    "/min: must be < 1",
+		`{"max": 1e99999999999999999999}`:    "/max: must be <= 1e300",
+		`{"multiple": 25e-1002}`:             "/multiple: must be a multiple of 0.25",
+		`{"multiple": 1e-2000}`:              "/multiple: must be a multiple of 0.25",
+		`{"three": 1e2000}`:                  "/three: must be a multiple of 3",
+		`{"multiple": 1e-99999999999999999}`: "/multiple: must be a multiple of 0.25",
+	}
+	for data, expected := range invalid {
+		typed, _ := JsonString(data)
+		equal(t, s.Validate(typed).Error(), expected)
+	}
+}
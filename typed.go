@@ -6,7 +6,7 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
-	"strconv"
+	"math"
 	"strings"
 	"time"
 )
@@ -16,6 +16,14 @@ var (
 	// present in the type
 	KeyNotFound = errors.New("Key not found")
 	Empty       = Typed(nil)
+
+	// Used by SetPath and DeletePath to indicate that the
+	// path is malformed or contains a wildcard
+	InvalidPath = errors.New("Invalid path")
+
+	// Used by SetPath to indicate that a segment of the path
+	// traverses a value which isn't an object or an array
+	PathConflict = errors.New("Path conflicts with an existing value")
 )
 
 // A Typed type helper for accessing a map
@@ -139,11 +147,21 @@ func (t Typed) BoolOr(key string, d bool) bool {
 func (t Typed) BoolMust(key string) bool {
 	b, exists := t.BoolIf(key)
 	if exists == false {
-		panic("expected boolean value for " + key)
+		panic(t.keyError(key, "boolean"))
 	}
 	return b
 }
 
+// Returns a bool or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) BoolE(key string) (bool, error) {
+	b, exists := t.BoolIf(key)
+	if exists == false {
+		return b, t.keyError(key, "boolean")
+	}
+	return b, nil
+}
+
 // Returns a boolean at the key and whether
 // or not the key existed and the value was a bolean
 func (t Typed) BoolIf(key string) (bool, bool) {
@@ -151,10 +169,7 @@ func (t Typed) BoolIf(key string) (bool, bool) {
 	if exists == false {
 		return false, false
 	}
-	if n, ok := value.(bool); ok {
-		return n, true
-	}
-	return false, false
+	return toBool(value)
 }
 
 func (t Typed) Int(key string) int {
@@ -174,38 +189,30 @@ func (t Typed) IntOr(key string, d int) int {
 func (t Typed) IntMust(key string) int {
 	i, exists := t.IntIf(key)
 	if exists == false {
-		panic("expected int value for " + key)
+		panic(t.keyError(key, "int"))
 	}
 	return i
 }
 
+// Returns an int or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) IntE(key string) (int, error) {
+	i, exists := t.IntIf(key)
+	if exists == false {
+		return i, t.keyError(key, "int")
+	}
+	return i, nil
+}
+
 // Returns an int at the key and whether
-// or not the key existed and the value was an int
+// or not the key existed and the value was an int.
+// Floats, including a json.Number, are truncated
 func (t Typed) IntIf(key string) (int, bool) {
 	value, exists := t[key]
 	if exists == false {
 		return 0, false
 	}
-
-	switch t := value.(type) {
-	case int:
-		return t, true
-	case int16:
-		return int(t), true
-	case int32:
-		return int(t), true
-	case int64:
-		return int(t), true
-	case float64:
-		return int(t), true
-	case string:
-		i, err := strconv.Atoi(t)
-		return i, err == nil
-	case json.Number:
-		i, err := t.Int64()
-		return int(i), err == nil
-	}
-	return 0, false
+	return toInt(value)
 }
 
 func (t Typed) Float(key string) float64 {
@@ -225,11 +232,21 @@ func (t Typed) FloatOr(key string, d float64) float64 {
 func (t Typed) FloatMust(key string) float64 {
 	f, exists := t.FloatIf(key)
 	if exists == false {
-		panic("expected float value for " + key)
+		panic(t.keyError(key, "float"))
 	}
 	return f
 }
 
+// Returns a float or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) FloatE(key string) (float64, error) {
+	f, exists := t.FloatIf(key)
+	if exists == false {
+		return f, t.keyError(key, "float")
+	}
+	return f, nil
+}
+
 // Returns an float at the key and whether
 // or not the key existed and the value was an float
 func (t Typed) FloatIf(key string) (float64, bool) {
@@ -237,17 +254,7 @@ func (t Typed) FloatIf(key string) (float64, bool) {
 	if exists == false {
 		return 0, false
 	}
-	switch t := value.(type) {
-	case float64:
-		return t, true
-	case string:
-		f, err := strconv.ParseFloat(t, 10)
-		return f, err == nil
-	case json.Number:
-		f, err := t.Float64()
-		return f, err == nil
-	}
-	return 0, false
+	return toFloat(value)
 }
 
 func (t Typed) String(key string) string {
@@ -267,11 +274,21 @@ func (t Typed) StringOr(key string, d string) string {
 func (t Typed) StringMust(key string) string {
 	s, exists := t.StringIf(key)
 	if exists == false {
-		panic("expected string value for " + key)
+		panic(t.keyError(key, "string"))
 	}
 	return s
 }
 
+// Returns a string or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) StringE(key string) (string, error) {
+	s, exists := t.StringIf(key)
+	if exists == false {
+		return s, t.keyError(key, "string")
+	}
+	return s, nil
+}
+
 // Returns an string at the key and whether
 // or not the key existed and the value was an string
 func (t Typed) StringIf(key string) (string, bool) {
@@ -279,14 +296,13 @@ func (t Typed) StringIf(key string) (string, bool) {
 	if exists == false {
 		return "", false
 	}
-	if n, ok := value.(string); ok {
-		return n, true
-	}
-	return "", false
+	return toString(value)
 }
 
+// Returns a time at the key, or a zero time if it
+// doesn't exist, or if it isn't a time
 func (t Typed) Time(key string) time.Time {
-	return t.TimeOr(key, time.Now())
+	return t.TimeOr(key, time.Time{})
 }
 
 // Returns a time at the key, or the specified
@@ -302,20 +318,111 @@ func (t Typed) TimeOr(key string, d time.Time) time.Time {
 func (t Typed) TimeMust(key string) time.Time {
 	tt, exists := t.TimeIf(key)
 	if exists == false {
-		panic("expected time.Time value for " + key)
+		panic(t.keyError(key, "time.Time"))
 	}
 	return tt
 }
 
+// Returns a time.Time or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) TimeE(key string) (time.Time, error) {
+	tt, exists := t.TimeIf(key)
+	if exists == false {
+		return tt, t.keyError(key, "time.Time")
+	}
+	return tt, nil
+}
+
 // Returns an time.time at the key and whether
-// or not the key existed and the value was a time.Time
+// or not the key existed and the value was a time.Time.
+// Strings are parsed as RFC 3339 (with or without fractional
+// seconds). Numbers are treated as a Unix timestamp in seconds,
+// or in milliseconds when they're too large to be seconds.
 func (t Typed) TimeIf(key string) (time.Time, bool) {
 	value, exists := t[key]
 	if exists == false {
 		return time.Time{}, false
 	}
-	if n, ok := value.(time.Time); ok {
-		return n, true
+	return toTime(value)
+}
+
+// Timestamps at or above this are treated as milliseconds
+// (as seconds, it would be the year 33658)
+const unixMilliThreshold = 1e12
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch t := value.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		tt, err := time.Parse(time.RFC3339Nano, t)
+		return tt, err == nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return unixTime(i), true
+		}
+		if f, err := t.Float64(); err == nil {
+			return unixTimeFloat(f), true
+		}
+	case float64:
+		return unixTimeFloat(t), true
+	case int:
+		return unixTime(int64(t)), true
+	case int64:
+		return unixTime(t), true
+	}
+	return time.Time{}, false
+}
+
+func unixTime(i int64) time.Time {
+	if i >= unixMilliThreshold || i <= -unixMilliThreshold {
+		return time.Unix(i/1000, (i%1000)*int64(time.Millisecond)).UTC()
+	}
+	return time.Unix(i, 0).UTC()
+}
+
+func unixTimeFloat(f float64) time.Time {
+	if f >= unixMilliThreshold || f <= -unixMilliThreshold {
+		sec := math.Floor(f / 1000)
+		return time.Unix(int64(sec), int64(math.Round((f-sec*1000)*1e6))).UTC()
+	}
+	sec := math.Floor(f)
+	return time.Unix(int64(sec), int64(math.Round((f-sec)*1e9))).UTC()
+}
+
+// Returns a time at the key parsed with the first of the layouts
+// which succeeds, or a zero time. Values without a time zone are
+// parsed in the location (UTC when nil). A time.Time is returned as-is
+func (t Typed) TimeLayout(key string, loc *time.Location, layouts ...string) time.Time {
+	tt, _ := t.TimeLayoutIf(key, loc, layouts...)
+	return tt
+}
+
+// Returns a time at the key parsed with the first of the layouts
+// which succeeds, and whether or not the key existed and the value
+// could be parsed. Values without a time zone are parsed in the
+// location (UTC when nil). A time.Time is returned as-is
+func (t Typed) TimeLayoutIf(key string, loc *time.Location, layouts ...string) (time.Time, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return time.Time{}, false
+	}
+	return toTimeLayout(value, loc, layouts)
+}
+
+func toTimeLayout(value interface{}, loc *time.Location, layouts []string) (time.Time, bool) {
+	switch t := value.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		if loc == nil {
+			loc = time.UTC
+		}
+		for _, layout := range layouts {
+			if tt, err := time.ParseInLocation(layout, t, loc); err == nil {
+				return tt, true
+			}
+		}
 	}
 	return time.Time{}, false
 }
@@ -344,11 +451,21 @@ func (t Typed) ObjectOr(key string, d map[string]interface{}) Typed {
 
 // Returns an typed object or panics
 func (t Typed) ObjectMust(key string) Typed {
-	t, exists := t.ObjectIf(key)
+	o, exists := t.ObjectIf(key)
 	if exists == false {
-		panic("expected map for " + key)
+		panic(t.keyError(key, "map"))
 	}
-	return t
+	return o
+}
+
+// Returns a typed object or an *Error if the key doesn't exist
+// or its value can't be converted
+func (t Typed) ObjectE(key string) (Typed, error) {
+	o, exists := t.ObjectIf(key)
+	if exists == false {
+		return o, t.keyError(key, "map")
+	}
+	return o, nil
 }
 
 // Returns a Typed helper at the key and whether
@@ -358,6 +475,10 @@ func (t Typed) ObjectIf(key string) (Typed, bool) {
 	if exists == false {
 		return nil, false
 	}
+	return toObject(value)
+}
+
+func toObject(value interface{}) (Typed, bool) {
 	switch t := value.(type) {
 	case map[string]interface{}:
 		return Typed(t), true
@@ -384,11 +505,20 @@ func (t Typed) InterfaceOr(key string, d interface{}) interface{} {
 func (t Typed) InterfaceMust(key string) interface{} {
 	i, exists := t.InterfaceIf(key)
 	if exists == false {
-		panic("expected map for " + key)
+		panic(t.keyError(key, ""))
 	}
 	return i
 }
 
+// Returns an interface or an *Error if the key doesn't exist
+func (t Typed) InterfaceE(key string) (interface{}, error) {
+	i, exists := t.InterfaceIf(key)
+	if exists == false {
+		return nil, t.keyError(key, "")
+	}
+	return i, nil
+}
+
 // Returns an string at the key and whether
 // or not the key existed and the value was an string
 func (t Typed) InterfaceIf(key string) (interface{}, bool) {
@@ -423,6 +553,10 @@ func (t Typed) MapIf(key string) (map[string]interface{}, bool) {
 	if exists == false {
 		return nil, false
 	}
+	return toMap(value)
+}
+
+func toMap(value interface{}) (map[string]interface{}, bool) {
 	if n, ok := value.(map[string]interface{}); ok {
 		return n, true
 	}
@@ -451,6 +585,10 @@ func (t Typed) BoolsIf(key string) ([]bool, bool) {
 	if exists == false {
 		return nil, false
 	}
+	return toBools(value)
+}
+
+func toBools(value interface{}) ([]bool, bool) {
 	if n, ok := value.([]bool); ok {
 		return n, true
 	}
@@ -459,7 +597,7 @@ func (t Typed) BoolsIf(key string) ([]bool, bool) {
 		n := make([]bool, l)
 		var ok bool
 		for i := 0; i < l; i++ {
-			if n[i], ok = a[i].(bool); ok == false {
+			if n[i], ok = toBool(a[i]); ok == false {
 				return n, false
 			}
 		}
@@ -490,34 +628,25 @@ func (t Typed) IntsOr(key string, d []int) []int {
 // Returns a int slice + true if valid
 // Returns nil + false otherwise
 // (returns nil+false if one of the values is not a valid int)
+// An empty array is an empty slice + true
 func (t Typed) IntsIf(key string) ([]int, bool) {
 	value, exists := t[key]
 	if exists == false {
 		return nil, false
 	}
+	return toInts(value)
+}
+
+func toInts(value interface{}) ([]int, bool) {
 	if n, ok := value.([]int); ok {
 		return n, true
 	}
 	if a, ok := value.([]interface{}); ok {
 		l := len(a)
-		if l == 0 {
-			return nil, false
-		}
-
 		n := make([]int, l)
+		var ok bool
 		for i := 0; i < l; i++ {
-			switch t := a[i].(type) {
-			case int:
-				n[i] = t
-			case float64:
-				n[i] = int(t)
-			case string:
-				_i, err := strconv.Atoi(t)
-				if err != nil {
-					return n, false
-				}
-				n[i] = _i
-			default:
+			if n[i], ok = toInt(a[i]); ok == false {
 				return n, false
 			}
 		}
@@ -545,45 +674,29 @@ func (t Typed) Ints64Or(key string, d []int64) []int64 {
 	return d
 }
 
-// Returns a boolean slice + true if valid
+// Returns a int64 slice + true if valid
 // Returns nil + false otherwise
-// (returns nil+false if one of the values is not a valid boolean)
+// (returns nil+false if one of the values is not a valid int64)
+// An empty array is an empty slice + true. Floats are truncated
+// like Int does (unlike Int64)
 func (t Typed) Ints64If(key string) ([]int64, bool) {
 	value, exists := t[key]
 	if exists == false {
 		return nil, false
 	}
+	return toInts64(value)
+}
+
+func toInts64(value interface{}) ([]int64, bool) {
 	if n, ok := value.([]int64); ok {
 		return n, true
 	}
 	if a, ok := value.([]interface{}); ok {
 		l := len(a)
-		if l == 0 {
-			return nil, false
-		}
-
 		n := make([]int64, l)
+		var ok bool
 		for i := 0; i < l; i++ {
-			switch t := a[i].(type) {
-			case int64:
-				n[i] = t
-			case float64:
-				n[i] = int64(t)
-			case int:
-				n[i] = int64(t)
-			case string:
-				_i, err := strconv.ParseInt(t, 10, 10)
-				if err != nil {
-					return n, false
-				}
-				n[i] = _i
-			case json.Number:
-				_i, err := t.Int64()
-				if err != nil {
-					return n, false
-				}
-				n[i] = _i
-			default:
+			if n[i], ok = toTruncatedInt64(a[i]); ok == false {
 				return n, false
 			}
 		}
@@ -615,29 +728,19 @@ func (t Typed) FloatsIf(key string) ([]float64, bool) {
 	if exists == false {
 		return nil, false
 	}
+	return toFloats(value)
+}
+
+func toFloats(value interface{}) ([]float64, bool) {
 	if n, ok := value.([]float64); ok {
 		return n, true
 	}
 	if a, ok := value.([]interface{}); ok {
 		l := len(a)
 		n := make([]float64, l)
+		var ok bool
 		for i := 0; i < l; i++ {
-			switch t := a[i].(type) {
-			case float64:
-				n[i] = t
-			case string:
-				f, err := strconv.ParseFloat(t, 10)
-				if err != nil {
-					return n, false
-				}
-				n[i] = f
-			case json.Number:
-				f, err := t.Float64()
-				if err != nil {
-					return n, false
-				}
-				n[i] = f
-			default:
+			if n[i], ok = toFloat(a[i]); ok == false {
 				return n, false
 			}
 		}
@@ -669,6 +772,10 @@ func (t Typed) StringsIf(key string) ([]string, bool) {
 	if exists == false {
 		return nil, false
 	}
+	return toStrings(value)
+}
+
+func toStrings(value interface{}) ([]string, bool) {
 	if n, ok := value.([]string); ok {
 		return n, true
 	}
@@ -677,7 +784,52 @@ func (t Typed) StringsIf(key string) ([]string, bool) {
 		n := make([]string, l)
 		var ok bool
 		for i := 0; i < l; i++ {
-			if n[i], ok = a[i].(string); ok == false {
+			if n[i], ok = toString(a[i]); ok == false {
+				return n, false
+			}
+		}
+		return n, true
+	}
+	return nil, false
+}
+
+// Returns an slice of times, or a nil slice
+func (t Typed) Times(key string) []time.Time {
+	return t.TimesOr(key, nil)
+}
+
+// Returns an slice of times, or the specified slice
+// if the key doesn't exist or isn't a valid []time.Time
+func (t Typed) TimesOr(key string, d []time.Time) []time.Time {
+	n, ok := t.TimesIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a time slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid time)
+// Values are parsed the same way as TimeIf
+func (t Typed) TimesIf(key string) ([]time.Time, bool) {
+	value, exists := t[key]
+	if exists == false {
+		return nil, false
+	}
+	return toTimes(value)
+}
+
+func toTimes(value interface{}) ([]time.Time, bool) {
+	if n, ok := value.([]time.Time); ok {
+		return n, true
+	}
+	if a, ok := value.([]interface{}); ok {
+		l := len(a)
+		n := make([]time.Time, l)
+		var ok bool
+		for i := 0; i < l; i++ {
+			if n[i], ok = toTime(a[i]); ok == false {
 				return n, false
 			}
 		}
@@ -695,30 +847,35 @@ func (t Typed) Objects(key string) []Typed {
 // Returns a slice of Typed helpers and true if exists, otherwise; nil and false.
 func (t Typed) ObjectsIf(key string) ([]Typed, bool) {
 	value, exists := t[key]
-	if exists == true {
-		switch t := value.(type) {
-		case []interface{}:
-			l := len(t)
-			n := make([]Typed, l)
-			for i := 0; i < l; i++ {
-				switch it := t[i].(type) {
-				case map[string]interface{}:
-					n[i] = Typed(it)
-				case Typed:
-					n[i] = it
-				}
-			}
-			return n, true
-		case []map[string]interface{}:
-			l := len(t)
-			n := make([]Typed, l)
-			for i := 0; i < l; i++ {
-				n[i] = Typed(t[i])
+	if exists == false {
+		return nil, false
+	}
+	return toObjects(value)
+}
+
+func toObjects(value interface{}) ([]Typed, bool) {
+	switch t := value.(type) {
+	case []interface{}:
+		l := len(t)
+		n := make([]Typed, l)
+		for i := 0; i < l; i++ {
+			switch it := t[i].(type) {
+			case map[string]interface{}:
+				n[i] = Typed(it)
+			case Typed:
+				n[i] = it
 			}
-			return n, true
-		case []Typed:
-			return t, true
 		}
+		return n, true
+	case []map[string]interface{}:
+		l := len(t)
+		n := make([]Typed, l)
+		for i := 0; i < l; i++ {
+			n[i] = Typed(t[i])
+		}
+		return n, true
+	case []Typed:
+		return t, true
 	}
 	return nil, false
 }
@@ -726,44 +883,109 @@ func (t Typed) ObjectsIf(key string) ([]Typed, bool) {
 func (t Typed) ObjectsMust(key string) []Typed {
 	value, exists := t.ObjectsIf(key)
 	if exists == false {
-		panic("expected objects value for " + key)
+		panic(t.keyError(key, "objects"))
 	}
 
 	return value
 }
 
+// Returns a slice of Typed helpers or an *Error if the
+// key doesn't exist or its value isn't an array
+func (t Typed) ObjectsE(key string) ([]Typed, error) {
+	value, exists := t.ObjectsIf(key)
+	if exists == false {
+		return nil, t.keyError(key, "objects")
+	}
+	return value, nil
+}
+
 // Returns an slice of map[string]interfaces, or a nil slice
 func (t Typed) Maps(key string) []map[string]interface{} {
+	return t.MapsOr(key, nil)
+}
+
+// Returns an slice of map[string]interfaces, or the specified slice
+// if the key doesn't exist or isn't a valid []map[string]interface{}
+func (t Typed) MapsOr(key string, d []map[string]interface{}) []map[string]interface{} {
+	n, ok := t.MapsIf(key)
+	if ok {
+		return n
+	}
+	return d
+}
+
+// Returns a map[string]interface{} slice + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not an object)
+// Elements can be raw maps or Typed
+func (t Typed) MapsIf(key string) ([]map[string]interface{}, bool) {
 	value, exists := t[key]
-	if exists == true {
-		if a, ok := value.([]interface{}); ok {
-			l := len(a)
-			n := make([]map[string]interface{}, l)
-			for i := 0; i < l; i++ {
-				n[i] = a[i].(map[string]interface{})
+	if exists == false {
+		return nil, false
+	}
+	return toMaps(value)
+}
+
+func toMaps(value interface{}) ([]map[string]interface{}, bool) {
+	switch a := value.(type) {
+	case []map[string]interface{}:
+		return a, true
+	case []Typed:
+		n := make([]map[string]interface{}, len(a))
+		for i, o := range a {
+			n[i] = o
+		}
+		return n, true
+	case []interface{}:
+		n := make([]map[string]interface{}, len(a))
+		for i, v := range a {
+			o, ok := toObject(v)
+			if ok == false {
+				return nil, false
 			}
-			return n
+			n[i] = o
 		}
+		return n, true
 	}
-	return nil
+	return nil, false
 }
 
-// Returns an map[string]bool
+// Returns an map[string]bool, or nil
 func (t Typed) StringBool(key string) map[string]bool {
+	return t.StringBoolOr(key, nil)
+}
+
+// Returns an map[string]bool, or the specified map if the
+// key doesn't exist or one of the values is not a valid boolean
+func (t Typed) StringBoolOr(key string, d map[string]bool) map[string]bool {
+	m, ok := t.StringBoolIf(key)
+	if ok {
+		return m
+	}
+	return d
+}
+
+// Returns an map[string]bool + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid boolean)
+func (t Typed) StringBoolIf(key string) (map[string]bool, bool) {
 	raw, ok := t.getmap(key)
 	if ok == false {
-		return nil
+		return nil, false
 	}
 	m := make(map[string]bool, len(raw))
 	for k, value := range raw {
-		m[k] = value.(bool)
+		v, ok := toBool(value)
+		if ok == false {
+			return nil, false
+		}
+		m[k] = v
 	}
-	return m
+	return m, true
 }
 
 // Returns an map[string]int
-// Some work is done to handle the fact that JSON ints
-// are represented as floats.
+// (returns nil if one of the values is not a valid int)
 func (t Typed) StringInt(key string) map[string]int {
 	raw, ok := t.getmap(key)
 	if ok == false {
@@ -771,29 +993,17 @@ func (t Typed) StringInt(key string) map[string]int {
 	}
 	m := make(map[string]int, len(raw))
 	for k, value := range raw {
-		switch t := value.(type) {
-		case int:
-			m[k] = t
-		case float64:
-			m[k] = int(t)
-		case string:
-			i, err := strconv.Atoi(t)
-			if err != nil {
-				return nil
-			}
-			m[k] = i
-		case json.Number:
-			i, err := t.Int64()
-			if err != nil {
-				return nil
-			}
-			m[k] = int(i)
+		v, ok := toInt(value)
+		if ok == false {
+			return nil
 		}
+		m[k] = v
 	}
 	return m
 }
 
 // Returns an map[string]float64
+// (returns nil if one of the values is not a valid float64)
 func (t Typed) StringFloat(key string) map[string]float64 {
 	raw, ok := t.getmap(key)
 	if ok == false {
@@ -801,52 +1011,99 @@ func (t Typed) StringFloat(key string) map[string]float64 {
 	}
 	m := make(map[string]float64, len(raw))
 	for k, value := range raw {
-		switch t := value.(type) {
-		case float64:
-			m[k] = t
-		case string:
-			f, err := strconv.ParseFloat(t, 10)
-			if err != nil {
-				return nil
-			}
-			m[k] = f
-		case json.Number:
-			f, err := t.Float64()
-			if err != nil {
-				return nil
-			}
-			m[k] = f
-		default:
+		v, ok := toFloat(value)
+		if ok == false {
 			return nil
 		}
+		m[k] = v
 	}
 	return m
 }
 
-// Returns an map[string]string
-func (t Typed) StringString(key string) map[string]string {
+// Returns an map[string]time.Time
+// Values are parsed the same way as TimeIf
+func (t Typed) StringTime(key string) map[string]time.Time {
 	raw, ok := t.getmap(key)
 	if ok == false {
 		return nil
 	}
-	m := make(map[string]string, len(raw))
+	m := make(map[string]time.Time, len(raw))
 	for k, value := range raw {
-		m[k] = value.(string)
+		tt, ok := toTime(value)
+		if ok == false {
+			return nil
+		}
+		m[k] = tt
 	}
 	return m
 }
 
-// Returns an map[string]Typed
+// Returns an map[string]string, or nil
+func (t Typed) StringString(key string) map[string]string {
+	return t.StringStringOr(key, nil)
+}
+
+// Returns an map[string]string, or the specified map if the
+// key doesn't exist or one of the values is not a valid string
+func (t Typed) StringStringOr(key string, d map[string]string) map[string]string {
+	m, ok := t.StringStringIf(key)
+	if ok {
+		return m
+	}
+	return d
+}
+
+// Returns an map[string]string + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid string)
+func (t Typed) StringStringIf(key string) (map[string]string, bool) {
+	raw, ok := t.getmap(key)
+	if ok == false {
+		return nil, false
+	}
+	m := make(map[string]string, len(raw))
+	for k, value := range raw {
+		v, ok := toString(value)
+		if ok == false {
+			return nil, false
+		}
+		m[k] = v
+	}
+	return m, true
+}
+
+// Returns an map[string]Typed, or nil
 func (t Typed) StringObject(key string) map[string]Typed {
+	return t.StringObjectOr(key, nil)
+}
+
+// Returns an map[string]Typed, or the specified map if the
+// key doesn't exist or one of the values is not a valid object
+func (t Typed) StringObjectOr(key string, d map[string]Typed) map[string]Typed {
+	m, ok := t.StringObjectIf(key)
+	if ok {
+		return m
+	}
+	return d
+}
+
+// Returns an map[string]Typed + true if valid
+// Returns nil + false otherwise
+// (returns nil+false if one of the values is not a valid object)
+func (t Typed) StringObjectIf(key string) (map[string]Typed, bool) {
 	raw, ok := t.getmap(key)
 	if ok == false {
-		return nil
+		return nil, false
 	}
 	m := make(map[string]Typed, len(raw))
 	for k, value := range raw {
-		m[k] = Typed(value.(map[string]interface{}))
+		v, ok := toObject(value)
+		if ok == false {
+			return nil, false
+		}
+		m[k] = v
 	}
-	return m
+	return m, true
 }
 
 // Marhals the type into a []byte.
@@ -887,11 +1144,11 @@ func (t Typed) Exists(key string) bool {
 	return exists
 }
 
-func (t Typed) getmap(key string) (raw map[string]interface{}, exists bool) {
+// Returns the object at the key, which can be a raw map or a Typed
+func (t Typed) getmap(key string) (map[string]interface{}, bool) {
 	value, exists := t[key]
 	if exists == false {
-		return
+		return nil, false
 	}
-	raw, exists = value.(map[string]interface{})
-	return
+	return toObject(value)
 }
@@ -291,8 +291,12 @@ func Test_Ints64(t *testing.T) {
 }
 
 func Test_Ints_WithFloats(t *testing.T) {
-	typed := New(build("scores", []interface{}{2.1, 7.39}))
+	typed := New(build("scores", []interface{}{2.1, 7.39}, "numbers", []interface{}{json.Number("1.5"), json.Number("2.0")}, "huge", []interface{}{1e19}))
 	equalList(t, typed.Ints("scores"), []int{2, 7})
+	equalList(t, typed.Ints64("scores"), []int64{2, 7})
+	equalList(t, typed.Ints64("numbers"), []int64{1, 2})
+	_, ok := typed.Ints64If("huge")
+	equal(t, ok, false)
 }
 
 func Test_Floats(t *testing.T) {
@@ -426,6 +430,49 @@ func Test_StringObject(t *testing.T) {
 	equal(t, m["goku"].Int("power"), 9001)
 }
 
+func Test_MapsIf(t *testing.T) {
+	typed := New(build("names", []interface{}{build("first", 1), Typed(build("second", 2))}, "typeds", []Typed{Typed(build("third", 3))}, "fail", []interface{}{build("first", 1), "nope"}, "nope", 1))
+	values, ok := typed.MapsIf("names")
+	equal(t, ok, true)
+	equal(t, values[1]["second"], 2)
+	equal(t, typed.Maps("typeds")[0]["third"], 3)
+
+	values, ok = typed.MapsIf("fail")
+	equal(t, len(values), 0)
+	equal(t, ok, false)
+	equal(t, len(typed.Maps("fail")), 0)
+	equal(t, len(typed.Maps("nope")), 0)
+	equal(t, typed.MapsOr("other", []map[string]interface{}{build("a", 1)})[0]["a"], 1)
+}
+
+func Test_StringMapsIf(t *testing.T) {
+	typed, _ := JsonString(`{"bools": {"a": true, "b": "no"}, "strings": {"a": "b", "c": 1}, "objects": {"a": {"b": 1}, "c": 2}, "nope": 1}`)
+	typed["nested"] = Typed(build("a", true))
+
+	bools, ok := typed.StringBoolIf("bools")
+	equal(t, len(bools), 0)
+	equal(t, ok, false)
+	equal(t, typed.StringBool("bools") == nil, true)
+	equal(t, typed.StringBoolOr("bools", map[string]bool{"x": true})["x"], true)
+	equal(t, typed.StringBool("nested")["a"], true)
+
+	strings, ok := typed.StringStringIf("strings")
+	equal(t, len(strings), 0)
+	equal(t, ok, false)
+	equal(t, typed.StringStringOr("nope", map[string]string{"x": "y"})["x"], "y")
+
+	objects, ok := typed.StringObjectIf("objects")
+	equal(t, len(objects), 0)
+	equal(t, ok, false)
+	equal(t, len(typed.StringObjectOr("other", nil)), 0)
+
+	typed["objects"] = build("a", Typed(build("b", 1)), "c", build("d", 2))
+	objects, ok = typed.StringObjectIf("objects")
+	equal(t, ok, true)
+	equal(t, objects["a"].Int("b"), 1)
+	equal(t, objects["c"].Int("d"), 2)
+}
+
 func Test_ToBytes(t *testing.T) {
 	typed, _ := JsonString(`{"atreides":{"leto":{"sister": "ghanima"}}, "goku": {"power": 9001}}`)
 	m, err := typed.ToBytes("goku")
@@ -514,6 +561,8 @@ func Test_Time(t *testing.T) {
 	equal(t, typed.Time("ts"), now)
 	equal(t, typed.TimeOr("ts", zero), now)
 	equal(t, typed.TimeOr("other", zero), zero)
+	equal(t, typed.Time("other"), zero)
+	equal(t, typed.Time("nope"), zero)
 
 	ts, exists := typed.TimeIf("ts")
 	equal(t, ts, now)
@@ -534,6 +583,76 @@ func Test_Time(t *testing.T) {
 	t.FailNow()
 }
 
+func Test_TimeParsing(t *testing.T) {
+	typed, _ := JsonString(`{"rfc": "2020-01-02T03:04:05Z", "nano": "2020-01-02T03:04:05.123456789+02:00", "seconds": 1577934245, "millis": 1577934245123, "fraction": 1577934245.5, "negative": -86400, "bad": "2020-01-02", "nope": true}`)
+	equal(t, typed.Time("rfc"), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	equal(t, typed.Time("nano").Equal(time.Date(2020, 1, 2, 1, 4, 5, 123456789, time.UTC)), true)
+	equal(t, typed.Time("seconds"), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	equal(t, typed.Time("millis"), time.Date(2020, 1, 2, 3, 4, 5, 123000000, time.UTC))
+	equal(t, typed.Time("fraction"), time.Date(2020, 1, 2, 3, 4, 5, 500000000, time.UTC))
+	equal(t, typed.Time("negative"), time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	_, exists := typed.TimeIf("bad")
+	equal(t, exists, false)
+	_, exists = typed.TimeIf("nope")
+	equal(t, exists, false)
+
+	typed = New(build("f64", 1577934245123.0, "int", 1577934245, "i64", int64(1577934245)))
+	equal(t, typed.Time("f64"), time.Date(2020, 1, 2, 3, 4, 5, 123000000, time.UTC))
+	equal(t, typed.Time("int"), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	equal(t, typed.Time("i64"), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+}
+
+func Test_TimeLayout(t *testing.T) {
+	now := time.Now()
+	est := time.FixedZone("EST", -5*3600)
+	typed := New(build("date", "2020-01-02", "us", "01/02/2020 03:04", "ts", now, "nope", 1))
+	equal(t, typed.TimeLayout("date", nil, "2006-01-02"), time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))
+	equal(t, typed.TimeLayout("us", est, "2006-01-02", "01/02/2006 15:04"), time.Date(2020, 1, 2, 3, 4, 0, 0, est))
+	equal(t, typed.TimeLayout("ts", nil, "2006-01-02"), now)
+
+	value, exists := typed.TimeLayoutIf("us", nil, "2006-01-02")
+	equal(t, value, time.Time{})
+	equal(t, exists, false)
+
+	_, exists = typed.TimeLayoutIf("nope", nil, "2006-01-02")
+	equal(t, exists, false)
+
+	_, exists = typed.TimeLayoutIf("other", nil, "2006-01-02")
+	equal(t, exists, false)
+}
+
+func Test_Times(t *testing.T) {
+	now := time.Now()
+	typed, _ := JsonString(`{"ts": ["2020-01-02T03:04:05Z", 1577934245], "fail": ["2020-01-02T03:04:05Z", "nope"], "nope": 1}`)
+	expected := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	values := typed.Times("ts")
+	equal(t, len(values), 2)
+	equal(t, values[0], expected)
+	equal(t, values[1], expected)
+	equal(t, len(typed.Times("other")), 0)
+	equal(t, typed.TimesOr("other", []time.Time{now})[0], now)
+	equal(t, New(build("times", []time.Time{now})).Times("times")[0], now)
+
+	values, exists := typed.TimesIf("fail")
+	equal(t, len(values), 2)
+	equal(t, values[0], expected)
+	equal(t, exists, false)
+
+	values, exists = typed.TimesIf("nope")
+	equal(t, len(values), 0)
+	equal(t, exists, false)
+}
+
+func Test_StringTime(t *testing.T) {
+	typed, _ := JsonString(`{"events": {"created": "2020-01-02T03:04:05Z", "updated": 1577934245123}, "fail": {"a": "nope"}}`)
+	m := typed.StringTime("events")
+	equal(t, m["created"], time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	equal(t, m["updated"], time.Date(2020, 1, 2, 3, 4, 5, 123000000, time.UTC))
+	equal(t, len(typed.StringTime("fail")), 0)
+	equal(t, len(typed.StringTime("other")), 0)
+}
+
 func build(values ...interface{}) map[string]interface{} {
 	m := make(map[string]interface{}, len(values))
 	for i := 0; i < len(values); i += 2 {
@@ -0,0 +1,308 @@
+package typed
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A validation rule for a field (see Field) or for the elements
+// of an array (see Value). Rules are built by chaining:
+//
+//	typed.Field("port").Required().IsInt().Min(1).Max(65535)
+type Rule struct {
+	key      string
+	required bool
+	kind     string
+	min      *big.Rat
+	max      *big.Rat
+	minLen   int
+	maxLen   int
+	pattern  *regexp.Regexp
+	enum     []interface{}
+	oneOf    []*Rule
+	fields   []*Rule
+	each     *Rule
+}
+
+// A rule which failed
+type Violation struct {
+	// The dotted path of the value (e.g. "servers[1].port")
+	Path string
+	// The rule which failed: "required", "type", "min", "max",
+	// "length", "pattern", "enum" or "oneOf"
+	Rule    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Path + " " + v.Message
+}
+
+// The violations returned by Validate
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.String()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Creates a rule for the value at the key
+func Field(key string) *Rule {
+	return &Rule{key: key, minLen: -1, maxLen: -1}
+}
+
+// Creates a rule for each element of an array (see Each)
+func Value() *Rule {
+	return Field("")
+}
+
+// The value must exist
+func (r *Rule) Required() *Rule {
+	r.required = true
+	return r
+}
+
+// The value must be a string
+func (r *Rule) IsString() *Rule {
+	r.kind = "string"
+	return r
+}
+
+// The value must be a number without a fractional part
+func (r *Rule) IsInt() *Rule {
+	r.kind = "integer"
+	return r
+}
+
+// The value must be a number
+func (r *Rule) IsNumber() *Rule {
+	r.kind = "number"
+	return r
+}
+
+// The value must be a boolean
+func (r *Rule) IsBool() *Rule {
+	r.kind = "boolean"
+	return r
+}
+
+// The value must be an array
+func (r *Rule) IsArray() *Rule {
+	r.kind = "array"
+	return r
+}
+
+// The value must be an object which satisfies the rules
+func (r *Rule) Object(fields ...*Rule) *Rule {
+	r.kind = "object"
+	r.fields = fields
+	return r
+}
+
+// The value must be an array whose elements satisfy the
+// rule (created with Value)
+func (r *Rule) Each(rule *Rule) *Rule {
+	r.kind = "array"
+	r.each = rule
+	return r
+}
+
+// A number must be greater than or equal to min, which
+// panics if it's NaN or infinite (like Pattern)
+func (r *Rule) Min(min float64) *Rule {
+	r.min = finiteRat("Min", min)
+	return r
+}
+
+// A number must be less than or equal to max, which
+// panics if it's NaN or infinite (like Pattern)
+func (r *Rule) Max(max float64) *Rule {
+	r.max = finiteRat("Max", max)
+	return r
+}
+
+// Returns the bound as the decimal it's written as (0.1, rather
+// than the binary fraction closest to it) like a document's numbers
+func finiteRat(name string, f float64) *big.Rat {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic("typed: " + name + "(" + s + ") isn't a finite number")
+	}
+	r, _ := new(big.Rat).SetString(s)
+	return r
+}
+
+// A string must have between min and max characters, or an
+// array between min and max elements. -1 means no limit
+func (r *Rule) Length(min int, max int) *Rule {
+	r.minLen, r.maxLen = min, max
+	return r
+}
+
+// A string must match the regular expression, which
+// panics if it doesn't compile (like regexp.MustCompile)
+func (r *Rule) Pattern(expr string) *Rule {
+	r.pattern = regexp.MustCompile(expr)
+	return r
+}
+
+// The value must be equal to one of the values
+// (numbers are compared by value, 1 equals 1.0)
+func (r *Rule) Enum(values ...interface{}) *Rule {
+	r.enum = values
+	return r
+}
+
+// The value must satisfy exactly one of the rules (created
+// with Value), e.g. a string or an array of strings
+func (r *Rule) OneOf(rules ...*Rule) *Rule {
+	r.oneOf = rules
+	return r
+}
+
+// Validates the document against the rules and returns every
+// violation (nil if there are none)
+func (t Typed) Validate(rules ...*Rule) Violations {
+	var violations Violations
+	for _, rule := range rules {
+		violations = rule.validateField("", t, violations)
+	}
+	return violations
+}
+
+func (r *Rule) validateField(path string, t Typed, violations Violations) Violations {
+	path = joinPath(path, r.key)
+	value, exists := t[r.key]
+	if exists == false {
+		if r.required {
+			violations = append(violations, Violation{Path: path, Rule: "required", Message: "is required"})
+		}
+		return violations
+	}
+	return r.validate(path, value, violations)
+}
+
+func (r *Rule) validate(path string, value interface{}, violations Violations) Violations {
+	fail := func(rule string, format string, args ...interface{}) Violations {
+		return append(violations, Violation{Path: path, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if r.kind != "" && isKind(value, r.kind) == false {
+		article := "a"
+		if r.kind == "integer" || r.kind == "object" || r.kind == "array" {
+			article = "an"
+		}
+		return fail("type", "must be %s %s", article, r.kind)
+	}
+
+	if r.enum != nil {
+		found := false
+		for _, e := range r.enum {
+			if equalValues(value, e) {
+				found = true
+				break
+			}
+		}
+		if found == false {
+			values := make([]string, len(r.enum))
+			for i, e := range r.enum {
+				values[i] = fmt.Sprint(e)
+			}
+			violations = fail("enum", "must be one of %s", strings.Join(values, ", "))
+		}
+	}
+
+	if r.min != nil {
+		if c, ok := compareNumber(value, r.min); ok && c < 0 {
+			violations = fail("min", "must be at least %s", formatRat(r.min))
+		}
+	}
+	if r.max != nil {
+		if c, ok := compareNumber(value, r.max); ok && c > 0 {
+			violations = fail("max", "must be at most %s", formatRat(r.max))
+		}
+	}
+
+	if r.minLen != -1 || r.maxLen != -1 {
+		l, unit := -1, ""
+		if s, ok := value.(string); ok {
+			l, unit = utf8.RuneCountInString(s), "characters"
+		} else if a, ok := toArray(value); ok {
+			l, unit = len(a), "elements"
+		}
+		if l != -1 && r.minLen != -1 && l < r.minLen {
+			violations = fail("length", "must have at least %d %s", r.minLen, unit)
+		}
+		if l != -1 && r.maxLen != -1 && l > r.maxLen {
+			violations = fail("length", "must have at most %d %s", r.maxLen, unit)
+		}
+	}
+
+	if r.pattern != nil {
+		if s, ok := value.(string); ok && r.pattern.MatchString(s) == false {
+			violations = fail("pattern", "must match %s", r.pattern)
+		}
+	}
+
+	if r.oneOf != nil {
+		matched := 0
+		for _, alternative := range r.oneOf {
+			if len(alternative.validate(path, value, nil)) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			violations = fail("oneOf", "must match exactly one of %d rules (matched %d)", len(r.oneOf), matched)
+		}
+	}
+
+	if r.fields != nil {
+		o, _ := toObject(value)
+		for _, field := range r.fields {
+			violations = field.validateField(path, o, violations)
+		}
+	}
+
+	if r.each != nil {
+		a, _ := toArray(value)
+		for i, element := range a {
+			violations = r.each.validate(path+"["+strconv.Itoa(i)+"]", element, violations)
+		}
+	}
+	return violations
+}
+
+func isKind(value interface{}, kind string) bool {
+	switch kind {
+	case "integer":
+		return isInteger(value)
+	case "object":
+		_, ok := toObject(value)
+		return ok
+	case "array":
+		_, ok := toArray(value)
+		return ok
+	}
+	return jsonType(value) == kind
+}
+
+// Returns the value as a number if it is one (strings aren't)
+func toNumber(value interface{}) (*big.Rat, bool) {
+	if _, ok := value.(string); ok {
+		return nil, false
+	}
+	return toRat(value)
+}
+
+func formatRat(r *big.Rat) string {
+	f, _ := r.Float64()
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,102 @@
+package typed
+
+import (
+	"math"
+	"testing"
+)
+
+var testRules = []*Rule{
+	Field("name").Required().IsString().Length(1, 5),
+	Field("age").IsInt().Min(0).Max(150),
+	Field("ratio").IsNumber().Min(0.5),
+	Field("email").IsString().Pattern(`^[^@]+@[^@]+$`),
+	Field("role").Enum("admin", "user"),
+	Field("level").Enum(1, 2),
+	Field("enabled").IsBool(),
+	Field("server").Required().Object(
+		Field("host").Required().IsString(),
+		Field("port").IsInt().Min(1).Max(65535),
+	),
+	Field("tags").Length(1, 2).Each(Value().IsString().Length(1, -1)),
+	Field("servers").Each(Value().Object(Field("port").Required().IsInt())),
+	Field("to").OneOf(Value().IsString(), Value().IsArray().Each(Value().IsString())),
+}
+
+func Test_ValidateValid(t *testing.T) {
+	typed, _ := JsonString(`{"name": "leto", "age": 30, "ratio": 0.5, "email": "leto@dune.gov", "role": "admin", "level": 2.0, "enabled": true,
+		"server": {"host": "localhost", "port": 80}, "tags": ["a", "b"], "servers": [{"port": 1}], "to": ["a"]}`)
+	equal(t, len(typed.Validate(testRules...)), 0)
+
+	typed, _ = JsonString(`{"name": "é", "server": {"host": "h"}, "to": "a"}`)
+	equal(t, len(typed.Validate(testRules...)), 0)
+}
+
+func Test_ValidateViolations(t *testing.T) {
+	typed, _ := JsonString(`{"name": "", "age": 30.5, "ratio": 0.25, "email": "leto", "role": "root", "level": 3, "enabled": "yes",
+		"server": {"port": 0}, "tags": ["a", "", "c"], "servers": [{"port": "1"}, 2], "to": 1}`)
+	violations := typed.Validate(testRules...)
+	expected := []Violation{
+		{"name", "length", "must have at least 1 characters"},
+		{"age", "type", "must be an integer"},
+		{"ratio", "min", "must be at least 0.5"},
+		{"email", "pattern", "must match ^[^@]+@[^@]+$"},
+		{"role", "enum", "must be one of admin, user"},
+		{"level", "enum", "must be one of 1, 2"},
+		{"enabled", "type", "must be a boolean"},
+		{"server.host", "required", "is required"},
+		{"server.port", "min", "must be at least 1"},
+		{"tags", "length", "must have at most 2 elements"},
+		{"tags[1]", "length", "must have at least 1 characters"},
+		{"servers[0].port", "type", "must be an integer"},
+		{"servers[1]", "type", "must be an object"},
+		{"to", "oneOf", "must match exactly one of 2 rules (matched 0)"},
+	}
+	equal(t, len(violations), len(expected))
+	for i, v := range violations {
+		equal(t, v, expected[i])
+	}
+
+	violations = New(build("age", 200)).Validate(testRules...)
+	equal(t, violations.Error(), "name is required; age must be at most 150; server is required")
+}
+
+func Test_ValidateEmptyDocument(t *testing.T) {
+	violations := Typed(nil).Validate(Field("id").Required(), Field("name").IsString())
+	equal(t, len(violations), 1)
+	equal(t, violations[0].Path, "id")
+}
+
+func Test_ValidateHugeExponents(t *testing.T) {
+	typed, _ := JsonString(`{"big": 1e2000, "small": 1e-2000}`)
+	violations := typed.Validate(Field("big").IsInt().Max(100), Field("small").IsInt().Min(0))
+	equal(t, violations.Error(), "big must be at most 100; small must be an integer")
+}
+
+func Test_ValidateDecimalBounds(t *testing.T) {
+	typed, _ := JsonString(`{"price": 0.1, "total": 0.3, "low": 0.09999, "high": 0.30001}`)
+	equal(t, len(typed.Validate(Field("price").Min(0.1), Field("total").Max(0.3))), 0)
+	typed["price"], typed["total"] = 0.1, 0.3
+	equal(t, len(typed.Validate(Field("price").Min(0.1), Field("total").Max(0.3))), 0)
+
+	violations := typed.Validate(Field("low").Min(0.1), Field("high").Max(0.3))
+	equal(t, violations.Error(), "low must be at least 0.1; high must be at most 0.3")
+}
+
+func Test_ValidateInvalidBounds(t *testing.T) {
+	for _, f := range []func(){
+		func() { Field("a").Min(math.NaN()) },
+		func() { Field("a").Max(math.Inf(1)) },
+		func() { Field("a").Min(math.Inf(-1)) },
+	} {
+		func() {
+			defer func() {
+				equal(t, recover() != nil, true)
+			}()
+			f()
+		}()
+	}
+
+	defer mustTest(t, "typed: Max(NaN) isn't a finite number")
+	Field("a").Max(math.NaN())
+	t.FailNow()
+}